@@ -0,0 +1,122 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// AnswersFile is the name of the file in a day directory that records the
+// known answers for that day's inputs.
+const AnswersFile = "answers.json"
+
+// Answer holds the known answers to both parts of one input. An empty
+// string means the answer is not known.
+type Answer struct {
+	Part1 string `json:"part1,omitempty"`
+	Part2 string `json:"part2,omitempty"`
+}
+
+// Part returns the known answer to part 1 or 2.
+func (a Answer) Part(part int) string {
+	if part == 1 {
+		return a.Part1
+	}
+
+	return a.Part2
+}
+
+// Set records the answer to part 1 or 2.
+func (a *Answer) Set(part int, answer string) {
+	if part == 1 {
+		a.Part1 = answer
+	} else {
+		a.Part2 = answer
+	}
+}
+
+// Answers maps an input file name, relative to the day directory, to its known answers.
+type Answers map[string]Answer
+
+// LoadAnswers reads the answers file of the day directory dir. A missing
+// file yields no answers and no error.
+func LoadAnswers(dir string) (Answers, error) {
+	answers, err := readAnswers(os.DirFS(dir), AnswersFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(Answers), nil
+	}
+
+	return answers, err
+}
+
+func readAnswers(fsys fs.FS, name string) (Answers, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("aoc: reading answers: %w", err)
+	}
+
+	answers := make(Answers)
+	if err := json.Unmarshal(content, &answers); err != nil {
+		return nil, fmt.Errorf("aoc: parsing %s: %w", name, err)
+	}
+
+	return answers, nil
+}
+
+// ExamplesDir is the directory, inside a day's embedded Examples, holding the
+// example inputs and an answers file with their expected answers.
+const ExamplesDir = "examples"
+
+// Example is a sample input from the puzzle statement.
+type Example struct {
+	Name  string
+	Input string
+	// Answer holds the expected answers; a part the example does not cover is empty.
+	Answer Answer
+}
+
+// LoadExamples returns the examples embedded by d, sorted by name. Every
+// example listed in the answers file must exist.
+func LoadExamples(d Day) ([]Example, error) {
+	if d.Examples == nil {
+		return nil, nil
+	}
+
+	answers, err := readAnswers(d.Examples, path.Join(ExamplesDir, AnswersFile))
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]Example, 0, len(answers))
+	for name, answer := range answers {
+		content, err := fs.ReadFile(d.Examples, path.Join(ExamplesDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("aoc: day %d example: %w", d.Day, err)
+		}
+
+		examples = append(examples, Example{Name: name, Input: string(content), Answer: answer})
+	}
+
+	sort.Slice(examples, func(i, j int) bool { return examples[i].Name < examples[j].Name })
+
+	return examples, nil
+}
+
+// Save writes the answers file of the day directory dir.
+func (a Answers) Save(dir string) error {
+	content, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, AnswersFile), append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("aoc: writing answers: %w", err)
+	}
+
+	return nil
+}
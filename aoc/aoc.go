@@ -0,0 +1,147 @@
+// Package aoc holds the pieces shared by every Go day: the Solver interface
+// each day implements and the registry the aoc runner looks days up in.
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Year is the Advent of Code event solved in this repository.
+const Year = 2023
+
+// Solver is implemented by every Go day. Parse is called once with the raw
+// puzzle input, after which PartOne and PartTwo may be called in any order.
+// Long-running work should stop with ctx.Err() once ctx is done and report
+// how far it got with ReportProgress.
+type Solver interface {
+	Parse(ctx context.Context, input string) error
+	PartOne(ctx context.Context) (any, error)
+	PartTwo(ctx context.Context) (any, error)
+}
+
+// Day describes a registered puzzle.
+type Day struct {
+	Day   int
+	Title string
+	// Dir is the directory holding the day's input.txt, relative to the repository root.
+	Dir string
+	New func() Solver
+	// Version identifies the solver's code in the answer cache: change it
+	// whenever the answers the solver gives could change. It is made of
+	// letters, digits, '.', '-' and '_'; days without one are never cached.
+	Version string
+	// Examples holds the example inputs of the puzzle statement under
+	// ExamplesDir, usually embedded by the day's package.
+	Examples fs.FS
+	// Generate, if set, writes random inputs for the day.
+	Generate Generator
+	// Alternates are other implementations of the day, run by the
+	// cross-check to confirm New's answers.
+	Alternates []Alternate
+}
+
+// Alternate is another algorithm for some parts of a day. The parts it does
+// not list are never asked of its solver.
+type Alternate struct {
+	Name  string
+	Parts []int
+	New   func() Solver
+}
+
+// Generator writes a random, valid puzzle input to w, about scale times the
+// size of a real input. It must draw all randomness from rng, so that the
+// same seed always gives the same input.
+type Generator func(w io.Writer, rng *rand.Rand, scale float64) error
+
+var (
+	mu   sync.RWMutex
+	days = make(map[int]Day)
+)
+
+// Register makes a day available to the runner. It is meant to be called
+// from the init function of the day's package and panics if the day is
+// registered twice.
+func Register(d Day) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if d.New == nil {
+		panic(fmt.Sprintf("aoc: Register day %d with nil constructor", d.Day))
+	}
+
+	for _, a := range d.Alternates {
+		if a.Name == "" || a.New == nil || len(a.Parts) == 0 {
+			panic(fmt.Sprintf("aoc: Register day %d with incomplete alternate %q", d.Day, a.Name))
+		}
+	}
+
+	for _, r := range d.Version {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune(".-_", r)) {
+			panic(fmt.Sprintf("aoc: Register day %d with invalid version %q", d.Day, d.Version))
+		}
+	}
+
+	if _, dup := days[d.Day]; dup {
+		panic(fmt.Sprintf("aoc: Register called twice for day %d", d.Day))
+	}
+
+	days[d.Day] = d
+}
+
+// Lookup returns the registered day with the given number.
+func Lookup(day int) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	d, ok := days[day]
+	return d, ok
+}
+
+// Days returns every registered day ordered by day number.
+func Days() []Day {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	out := make([]Day, 0, len(days))
+	for _, d := range days {
+		out = append(out, d)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Day < out[j].Day })
+
+	return out
+}
+
+// Parse parses input with s. If ctx is done first, Parse returns a
+// *TimeoutError without waiting for s.
+func Parse(ctx context.Context, s Solver, input string) error {
+	_, err := guard(ctx, func(ctx context.Context) (any, error) {
+		return nil, s.Parse(ctx, input)
+	})
+
+	return err
+}
+
+// Solve runs the given part (1 or 2) of a parsed solver. If ctx is done
+// first, Solve returns a *TimeoutError saying how far the part got, without
+// waiting for it.
+func Solve(ctx context.Context, s Solver, part int) (any, error) {
+	var run func(context.Context) (any, error)
+	switch part {
+	case 1:
+		run = s.PartOne
+	case 2:
+		run = s.PartTwo
+	default:
+		return nil, fmt.Errorf("aoc: unknown part %d", part)
+	}
+
+	return guard(ctx, run)
+}
@@ -0,0 +1,145 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type progressKey struct{}
+
+// progress is how far a running parse or part has got, as last reported
+// through ReportProgress.
+type progress struct {
+	mu    sync.Mutex
+	unit  string
+	done  int64
+	total int64
+}
+
+func (p *progress) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.unit == "":
+		return "no progress reported"
+	case p.total > 0:
+		return fmt.Sprintf("reached %d/%d %s (%.1f%%)", p.done, p.total, p.unit, 100*float64(p.done)/float64(p.total))
+	default:
+		return fmt.Sprintf("reached %d %s", p.done, p.unit)
+	}
+}
+
+// ReportProgress records that a long-running part has processed done of
+// total units, so that it can say how far it got if it is stopped. A total
+// of zero means the total is not known. It is safe to call from several
+// goroutines and does nothing when ctx does not come from Parse or Solve.
+func ReportProgress(ctx context.Context, unit string, done, total int) {
+	p, ok := ctx.Value(progressKey{}).(*progress)
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	p.unit, p.done, p.total = unit, int64(done), int64(total)
+	p.mu.Unlock()
+}
+
+// Counter is a concurrency-safe progress counter for parts that split their
+// work across goroutines.
+type Counter struct {
+	ctx   context.Context
+	unit  string
+	total int
+	done  atomic.Int64
+}
+
+// NewCounter returns a counter reporting units out of total through ctx.
+func NewCounter(ctx context.Context, unit string, total int) *Counter {
+	return &Counter{ctx: ctx, unit: unit, total: total}
+}
+
+// Add records n more units done.
+func (c *Counter) Add(n int) {
+	ReportProgress(c.ctx, c.unit, int(c.done.Add(int64(n))), c.total)
+}
+
+// TimeoutError is returned when the context of a parse or part is done
+// before it finishes.
+type TimeoutError struct {
+	Elapsed time.Duration
+	// Progress describes how far the work got, as last reported by it.
+	Progress string
+	Err      error
+}
+
+func (e *TimeoutError) Error() string {
+	verb := "cancelled"
+	if e.Err == context.DeadlineExceeded {
+		verb = "timed out"
+	}
+
+	return fmt.Sprintf("%s after %v, %s", verb, e.Elapsed.Round(time.Millisecond), e.Progress)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned when a parse or part panics.
+type PanicError struct {
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// guard runs fn with a progress tracker attached to ctx. It returns a
+// *TimeoutError as soon as ctx is done, without waiting for fn: work that
+// does not check ctx is left to finish in the background. A panic in fn is
+// returned as a *PanicError. fn's result only reaches the caller through
+// the return values, so an abandoned fn shares nothing with it.
+func guard(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+	p := &progress{}
+	ctx = context.WithValue(ctx, progressKey{}, p)
+
+	type result struct {
+		value any
+		err   error
+	}
+
+	start := time.Now()
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- result{err: &PanicError{Value: v, Stack: debug.Stack()}}
+			}
+		}()
+
+		v, err := fn(ctx)
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && ctx.Err() != nil {
+			return nil, &TimeoutError{Elapsed: time.Since(start), Progress: p.String(), Err: ctx.Err()}
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		return r.value, nil
+	case <-ctx.Done():
+		return nil, &TimeoutError{Elapsed: time.Since(start), Progress: p.String(), Err: ctx.Err()}
+	}
+}
@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Result is the outcome of one part of one day on one input.
+type Result struct {
+	Year  int
+	Day   int
+	Title string
+	Part  int
+	// Answer is the answer formatted with fmt.Sprint; empty when Err is set.
+	Answer   string
+	Duration time.Duration
+	// Input names the input, and InputHash is the hex SHA-256 of its content
+	// after normalisation by input.Load.
+	Input     string
+	InputHash string
+	// Cached is set when the answer came from the answer cache rather than
+	// from running the solver; Duration is then zero.
+	Cached bool
+	Err    error
+}
+
+// HashInput returns the hex SHA-256 of an input's content.
+func HashInput(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Run parses content, the input called name, with a fresh solver of d and
+// solves parts in order, timing each part. It stops at the first error. When
+// parsing fails no results are returned; when a part fails its result, with
+// Err set, is the last one returned and the error is that Err.
+func Run(ctx context.Context, d Day, name, content string, parts []int) ([]Result, error) {
+	solver := d.New()
+	if err := Parse(ctx, solver, content); err != nil {
+		return nil, err
+	}
+
+	hash := HashInput(content)
+	results := make([]Result, 0, len(parts))
+	for _, part := range parts {
+		start := time.Now()
+		answer, err := Solve(ctx, solver, part)
+
+		r := Result{
+			Year:      Year,
+			Day:       d.Day,
+			Title:     d.Title,
+			Part:      part,
+			Duration:  time.Since(start),
+			Input:     name,
+			InputHash: hash,
+		}
+
+		if err != nil {
+			r.Err = fmt.Errorf("part %d: %w", part, err)
+			return append(results, r), r.Err
+		}
+
+		r.Answer = fmt.Sprint(answer)
+		results = append(results, r)
+	}
+
+	return results, nil
+}
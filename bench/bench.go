@@ -0,0 +1,208 @@
+// Package bench times the phases of a solver over repeated runs and reports
+// their timing distribution and allocations.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+// The phases of a run, in the order they are executed. Total is the sum of
+// the others; a part the implementation does not solve has no phase.
+const (
+	Parse = "parse"
+	Part1 = "part1"
+	Part2 = "part2"
+	Total = "total"
+)
+
+// Stats summarises one phase over every measured run.
+type Stats struct {
+	Phase  string        `json:"phase"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	Allocs uint64        `json:"allocs_per_run"`
+	Bytes  uint64        `json:"bytes_per_run"`
+}
+
+// Result is the benchmark of one day on one input.
+type Result struct {
+	Day   int    `json:"day"`
+	Title string `json:"title"`
+	Input string `json:"input"`
+	// Impl names the implementation measured: the day's own solver or one
+	// of its alternates.
+	Impl   string  `json:"implementation"`
+	Runs   int     `json:"runs"`
+	Phases []Stats `json:"phases"`
+}
+
+// Phase returns the stats of the named phase.
+func (r Result) Phase(name string) Stats {
+	for _, s := range r.Phases {
+		if s.Phase == name {
+			return s
+		}
+	}
+
+	return Stats{Phase: name}
+}
+
+type sample struct {
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+}
+
+// Main names a day's own solver, as opposed to its alternates.
+const Main = "main"
+
+// Run parses content with a fresh solver of d and solves the parts it
+// implements, warmup times unmeasured and then runs times measured. impl
+// names the solver: Main, which solves both parts, or one of the alternates
+// of d, which solves only the parts it lists.
+func Run(d aoc.Day, impl, name, content string, runs, warmup int) (Result, error) {
+	if runs < 1 {
+		return Result{}, fmt.Errorf("bench: runs must be positive, got %d", runs)
+	}
+
+	parts := []int{1, 2}
+	if impl != Main {
+		alt, ok := alternate(d, impl)
+		if !ok {
+			return Result{}, fmt.Errorf("bench: day %d has no implementation %q", d.Day, impl)
+		}
+
+		d.New = alt.New
+		parts = alt.Parts
+	}
+
+	phases := []string{Parse}
+	for _, part := range parts {
+		phases = append(phases, partPhase[part])
+	}
+
+	samples := make(map[string][]sample, len(phases)+1)
+
+	for i := 0; i < warmup+runs; i++ {
+		run, err := runOnce(d, content, parts)
+		if err != nil {
+			return Result{}, err
+		}
+
+		if i < warmup {
+			continue
+		}
+
+		var total sample
+		for p, phase := range phases {
+			samples[phase] = append(samples[phase], run[p])
+			total.elapsed += run[p].elapsed
+			total.allocs += run[p].allocs
+			total.bytes += run[p].bytes
+		}
+
+		samples[Total] = append(samples[Total], total)
+	}
+
+	result := Result{Day: d.Day, Title: d.Title, Input: name, Impl: impl, Runs: runs}
+	for _, phase := range append(phases, Total) {
+		result.Phases = append(result.Phases, summarise(phase, samples[phase]))
+	}
+
+	return result, nil
+}
+
+// alternate returns the alternate of d called name.
+func alternate(d aoc.Day, name string) (aoc.Alternate, bool) {
+	for _, a := range d.Alternates {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return aoc.Alternate{}, false
+}
+
+// partPhase names the phase of each part.
+var partPhase = map[int]string{1: Part1, 2: Part2}
+
+// runOnce measures the parse phase of one run, then the phase of each of
+// parts in turn.
+func runOnce(d aoc.Day, content string, parts []int) ([]sample, error) {
+	run := make([]sample, 1+len(parts))
+
+	ctx := context.Background()
+	solver := d.New()
+	steps := []func() error{func() error { return solver.Parse(ctx, content) }}
+	for _, part := range parts {
+		solve := solver.PartOne
+		if part == 2 {
+			solve = solver.PartTwo
+		}
+
+		steps = append(steps, func() error { _, err := solve(ctx); return err })
+	}
+
+	var before, after runtime.MemStats
+	for i, step := range steps {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err := step()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if err != nil {
+			if i == 0 {
+				return run, err
+			}
+
+			return run, fmt.Errorf("part %d: %w", parts[i-1], err)
+		}
+
+		run[i] = sample{
+			elapsed: elapsed,
+			allocs:  after.Mallocs - before.Mallocs,
+			bytes:   after.TotalAlloc - before.TotalAlloc,
+		}
+	}
+
+	return run, nil
+}
+
+func summarise(phase string, samples []sample) Stats {
+	durations := make([]time.Duration, len(samples))
+	var allocs, bytes uint64
+	for i, s := range samples {
+		durations[i] = s.elapsed
+		allocs += s.allocs
+		bytes += s.bytes
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	n := len(durations)
+	median := durations[n/2]
+	if n%2 == 0 {
+		median = (durations[n/2-1] + durations[n/2]) / 2
+	}
+
+	// nearest-rank percentile
+	p95 := durations[int(math.Ceil(0.95*float64(n)))-1]
+
+	return Stats{
+		Phase:  phase,
+		Min:    durations[0],
+		Median: median,
+		P95:    p95,
+		Allocs: allocs / uint64(n),
+		Bytes:  bytes / uint64(n),
+	}
+}
@@ -0,0 +1,118 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+// Report is a set of results together with what is needed to compare it
+// with reports taken on other commits or machines.
+type Report struct {
+	Year      int       `json:"year"`
+	Revision  string    `json:"revision,omitempty"`
+	GoVersion string    `json:"go_version"`
+	Platform  string    `json:"platform"`
+	Time      time.Time `json:"time"`
+	Results   []Result  `json:"results"`
+}
+
+// NewReport returns a report of results stamped with the current time, the
+// Go toolchain and platform, and the VCS revision the binary was built from
+// when it is known.
+func NewReport(results []Result) Report {
+	report := Report{
+		Year:      aoc.Year,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Time:      time.Now().UTC().Truncate(time.Second),
+		Results:   results,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				report.Revision = s.Value
+			}
+		}
+	}
+
+	return report
+}
+
+// WriteJSON writes the report as indented JSON. Durations are nanoseconds.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one row per day, input and phase. Durations are nanoseconds.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"revision", "day", "title", "input", "implementation", "runs", "phase", "min_ns", "median_ns", "p95_ns", "allocs_per_run", "bytes_per_run"})
+
+	for _, res := range r.Results {
+		for _, s := range res.Phases {
+			cw.Write([]string{
+				r.Revision,
+				strconv.Itoa(res.Day),
+				res.Title,
+				res.Input,
+				res.Impl,
+				strconv.Itoa(res.Runs),
+				s.Phase,
+				strconv.FormatInt(int64(s.Min), 10),
+				strconv.FormatInt(int64(s.Median), 10),
+				strconv.FormatInt(int64(s.P95), 10),
+				strconv.FormatUint(s.Allocs, 10),
+				strconv.FormatUint(s.Bytes, 10),
+			})
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteText writes the report as an aligned table. Days whose median total
+// exceeds budget are marked; a zero budget disables the check.
+func (r Report) WriteText(w io.Writer, budget time.Duration) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tINPUT\tIMPL\tPHASE\tMIN\tMEDIAN\tP95\tALLOCS\tBYTES\t")
+
+	for _, res := range r.Results {
+		for _, s := range res.Phases {
+			note := ""
+			if s.Phase == Total && budget > 0 && s.Median > budget {
+				note = "over budget"
+			}
+
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%v\t%v\t%v\t%d\t%d\t%s\n",
+				res.Day, res.Input, res.Impl, s.Phase, Round(s.Min), Round(s.Median), Round(s.P95), s.Allocs, s.Bytes, note)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// Round keeps three significant digits, which is all a timing is good for.
+func Round(d time.Duration) time.Duration {
+	for unit := time.Duration(1); unit < time.Hour; unit *= 10 {
+		if d < 1000*unit {
+			return d.Round(unit)
+		}
+	}
+
+	return d
+}
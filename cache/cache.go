@@ -0,0 +1,142 @@
+// Package cache keeps the answers the runner computed on disk, keyed by the
+// SHA-256 of the input and the version of the solver that computed them, so
+// that an unchanged solver never solves the same input twice.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+// Cache is an answer cache rooted at a directory.
+type Cache struct {
+	dir string
+}
+
+// Open returns the answer cache inside root, the directory of the on-disk
+// cache. Nothing is created until an answer is stored.
+func Open(root string) *Cache {
+	return &Cache{dir: filepath.Join(root, "answers")}
+}
+
+// Dir returns the directory holding the cached answers.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// path returns the file holding the answers of d on the input with the
+// given hash, or "" when d declares no version and is never cached.
+func (c *Cache) path(d aoc.Day, hash string) string {
+	if d.Version == "" {
+		return ""
+	}
+
+	return filepath.Join(c.dayDir(d.Day), hash+"-v"+d.Version+".json")
+}
+
+func (c *Cache) dayDir(day int) string {
+	return filepath.Join(c.dir, fmt.Sprint(aoc.Year), fmt.Sprintf("day%02d", day))
+}
+
+func (c *Cache) load(path string) (aoc.Answer, error) {
+	var a aoc.Answer
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a, nil
+	}
+
+	if err != nil {
+		return a, fmt.Errorf("cache: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &a); err != nil {
+		return a, fmt.Errorf("cache: parsing %s: %w", path, err)
+	}
+
+	return a, nil
+}
+
+// Get returns the cached answer to part of d on the input with the given hash.
+func (c *Cache) Get(d aoc.Day, hash string, part int) (string, bool) {
+	path := c.path(d, hash)
+	if path == "" {
+		return "", false
+	}
+
+	a, err := c.load(path)
+	if err != nil {
+		return "", false
+	}
+
+	answer := a.Part(part)
+
+	return answer, answer != ""
+}
+
+// Put stores the answer to part of d on the input with the given hash. It
+// does nothing for a day that declares no version.
+func (c *Cache) Put(d aoc.Day, hash string, part int, answer string) error {
+	path := c.path(d, hash)
+	if path == "" {
+		return nil
+	}
+
+	a, err := c.load(path)
+	if err != nil {
+		return err
+	}
+
+	a.Set(part, answer)
+
+	content, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	// write through a temporary file so a reader never sees half an answer.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes every cached answer, or only those of day when it is not zero.
+func (c *Cache) Clear(day int) error {
+	dir := c.dir
+	if day != 0 {
+		dir = c.dayDir(day)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	return nil
+}
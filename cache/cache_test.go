@@ -0,0 +1,145 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/cache"
+)
+
+var (
+	day7  = aoc.Day{Day: 7, Version: "1"}
+	day9  = aoc.Day{Day: 9, Version: "1"}
+	input = aoc.HashInput("32T3K 765")
+)
+
+// get checks that part of d on the input with hash is cached as want, or
+// is not cached when want is empty.
+func get(t *testing.T, c *cache.Cache, d aoc.Day, hash string, part int, want string) {
+	t.Helper()
+
+	got, ok := c.Get(d, hash, part)
+	if got != want || ok != (want != "") {
+		t.Errorf("Get(day %d v%q, part %d) = %q, %t, want %q", d.Day, d.Version, part, got, ok, want)
+	}
+}
+
+func TestPutGet(t *testing.T) {
+	c := cache.Open(t.TempDir())
+
+	get(t, c, day7, input, 1, "")
+
+	if err := c.Put(day7, input, 1, "6440"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Put(day7, input, 2, "5905"); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, c, day7, input, 1, "6440")
+	get(t, c, day7, input, 2, "5905")
+
+	// a new answer replaces the old one and leaves the other part alone.
+	if err := c.Put(day7, input, 1, "6441"); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, c, day7, input, 1, "6441")
+	get(t, c, day7, input, 2, "5905")
+
+	// the answers survive reopening the cache.
+	get(t, cache.Open(filepath.Dir(c.Dir())), day7, input, 2, "5905")
+}
+
+func TestGetMisses(t *testing.T) {
+	c := cache.Open(t.TempDir())
+	if err := c.Put(day7, input, 1, "6440"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		d    aoc.Day
+		hash string
+	}{
+		{"another version", aoc.Day{Day: 7, Version: "2"}, input},
+		{"another input", day7, aoc.HashInput("32T3K 766")},
+		{"another day", aoc.Day{Day: 8, Version: "1"}, input},
+		{"no version", aoc.Day{Day: 7}, input},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			get(t, c, tt.d, tt.hash, 1, "")
+		})
+	}
+}
+
+func TestPutWithoutVersion(t *testing.T) {
+	c := cache.Open(t.TempDir())
+
+	if err := c.Put(aoc.Day{Day: 7}, input, 1, "6440"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(c.Dir()); !os.IsNotExist(err) {
+		t.Errorf("Put of a day without a version wrote to the cache: %v", err)
+	}
+}
+
+func TestCorruptEntry(t *testing.T) {
+	c := cache.Open(t.TempDir())
+	if err := c.Put(day7, input, 1, "6440"); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.Dir(), "*", "day07", "*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("cached files = %v, %v, want one", files, err)
+	}
+
+	if err := os.WriteFile(files[0], []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, c, day7, input, 1, "")
+
+	if err := c.Put(day7, input, 2, "5905"); err == nil {
+		t.Errorf("Put over a corrupt entry succeeded")
+	}
+}
+
+func TestClear(t *testing.T) {
+	c := cache.Open(t.TempDir())
+
+	for _, d := range []aoc.Day{day7, day9} {
+		if err := c.Put(d, input, 1, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.Clear(7); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, c, day7, input, 1, "")
+	get(t, c, day9, input, 1, "1")
+
+	if err := c.Clear(0); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, c, day9, input, 1, "")
+
+	if _, err := os.Stat(c.Dir()); !os.IsNotExist(err) {
+		t.Errorf("Clear(0) left %s: %v", c.Dir(), err)
+	}
+
+	// clearing an empty cache is not an error.
+	if err := c.Clear(0); err != nil {
+		t.Errorf("Clear of an empty cache: %v", err)
+	}
+}
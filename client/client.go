@@ -0,0 +1,154 @@
+// Package client talks to the Advent of Code website: it downloads puzzle
+// inputs into a per-user, per-account cache so that each input is fetched
+// only once.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// UserAgent identifies this tool to the website, as its maintainer asks
+// automated clients to do.
+const UserAgent = "github.com/unkn0wn-root/advent_of_code_2023"
+
+// ErrNoSession is returned when a request needs a session but none is configured.
+var ErrNoSession = errors.New("client: no session configured, set " + EnvSession + " or add \"session\" to the config file")
+
+// Client downloads puzzle inputs.
+type Client struct {
+	cfg  Config
+	HTTP *http.Client
+}
+
+// New returns a client for cfg.
+func New(cfg *Config) *Client {
+	return &Client{cfg: *cfg, HTTP: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *Client) baseURL() string {
+	if c.cfg.BaseURL == "" {
+		return DefaultBaseURL
+	}
+
+	return strings.TrimRight(c.cfg.BaseURL, "/")
+}
+
+// InputPath returns where the input of year/day is cached for the configured account.
+func (c *Client) InputPath(year, day int) (string, error) {
+	dir, err := c.cfg.CacheRoot()
+	if err != nil {
+		return "", err
+	}
+
+	account, err := c.cfg.AccountKey()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "inputs", account, fmt.Sprint(year), fmt.Sprintf("day%02d.txt", day)), nil
+}
+
+// InputFile returns the path of the cached input of year/day, downloading
+// it first if it is not cached yet. A cached input is never downloaded again.
+func (c *Client) InputFile(ctx context.Context, year, day int) (string, error) {
+	path, err := c.InputPath(year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("client: %w", err)
+	}
+
+	content, err := c.FetchInput(ctx, year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeFileAtomic(path, content); err != nil {
+		return "", fmt.Errorf("client: caching input: %w", err)
+	}
+
+	return path, nil
+}
+
+// FetchInput downloads the input of year/day, bypassing the cache.
+func (c *Client) FetchInput(ctx context.Context, year, day int) ([]byte, error) {
+	if c.cfg.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.baseURL(), year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("client: reading input of day %d: %w", day, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("client: fetching input of day %d: %s: %s", day, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// do sends req with the session cookie and user agent set.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.cfg.Session})
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	return resp, nil
+}
+
+// writeFileAtomic writes content to path through a temporary file so that
+// an interrupted download never leaves a truncated input in the cache.
+func writeFileAtomic(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/client"
+)
+
+// newServer starts a website that answers with handler and a client of it
+// that caches into a temporary directory.
+func newServer(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return client.New(&client.Config{Session: "secret", Account: "tester", BaseURL: srv.URL + "/", CacheDir: t.TempDir()})
+}
+
+func TestFetchInput(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/2023/day/7/input" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		if r.UserAgent() != client.UserAgent {
+			t.Errorf("User-Agent = %q, want %q", r.UserAgent(), client.UserAgent)
+		}
+
+		w.Write([]byte("32T3K 765\n"))
+	})
+
+	got, err := c.FetchInput(context.Background(), 2023, 7)
+	if err != nil || string(got) != "32T3K 765\n" {
+		t.Errorf("FetchInput = %q, %v, want %q", got, err, "32T3K 765\n")
+	}
+
+	_, err = c.FetchInput(context.Background(), 2023, 8)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("FetchInput of a missing day: error = %v, want a 404", err)
+	}
+}
+
+func TestFetchInputNeedsSession(t *testing.T) {
+	c := client.New(&client.Config{BaseURL: "http://127.0.0.1:0", CacheDir: t.TempDir()})
+
+	if _, err := c.FetchInput(context.Background(), 2023, 1); !errors.Is(err, client.ErrNoSession) {
+		t.Errorf("FetchInput without a session: error = %v, want ErrNoSession", err)
+	}
+}
+
+func TestInputFileIsFetchedOnce(t *testing.T) {
+	requests := 0
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("0 3 6 9 12 15\n"))
+	})
+
+	for i := 0; i < 2; i++ {
+		path, err := c.InputFile(context.Background(), 2023, 9)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != "0 3 6 9 12 15\n" {
+			t.Errorf("cached input = %q, %v", content, err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("the website got %d requests, want 1", requests)
+	}
+}
+
+func TestAccountKey(t *testing.T) {
+	tests := []struct {
+		account string
+		ok      bool
+	}{
+		{"alice", true},
+		{"alice.work", true},
+		{"..", false},
+		{".", false},
+		{"../../etc", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{"   ", false},
+	}
+
+	for _, tt := range tests {
+		cfg := client.Config{Session: "secret", Account: tt.account}
+
+		key, err := cfg.AccountKey()
+		if (err == nil) != tt.ok {
+			t.Errorf("AccountKey() of %q = %q, %v, want ok %t", tt.account, key, err, tt.ok)
+		}
+	}
+
+	anonymous, err := (&client.Config{}).AccountKey()
+	if err != nil || anonymous != "anonymous" {
+		t.Errorf("AccountKey() without a session = %q, %v, want anonymous", anonymous, err)
+	}
+
+	hashed, err := (&client.Config{Session: "secret"}).AccountKey()
+	if err != nil || strings.Contains(hashed, "secret") || filepath.Base(hashed) != hashed {
+		t.Errorf("AccountKey() of a session = %q, %v, want a hash of it", hashed, err)
+	}
+}
+
+func TestInputPathRejectsBadAccount(t *testing.T) {
+	c := client.New(&client.Config{Session: "secret", Account: "../..", CacheDir: t.TempDir()})
+
+	if path, err := c.InputPath(2023, 1); err == nil {
+		t.Errorf("InputPath = %q, want an error", path)
+	}
+}
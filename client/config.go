@@ -0,0 +1,132 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// environment variables that override the config file.
+const (
+	EnvConfig   = "AOC_CONFIG"
+	EnvSession  = "AOC_SESSION"
+	EnvAccount  = "AOC_ACCOUNT"
+	EnvBaseURL  = "AOC_BASE_URL"
+	EnvCacheDir = "AOC_CACHE_DIR"
+)
+
+// DefaultBaseURL is the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Config holds the settings needed to talk to the website.
+type Config struct {
+	// Session is the value of the "session" cookie of a logged in browser.
+	Session string `json:"session"`
+	// Account names the session owner. Inputs differ per account, so it is
+	// part of every cache key. When empty a hash of the session is used. It
+	// becomes a directory name, so it cannot contain a path separator or be
+	// "." or "..".
+	Account string `json:"account,omitempty"`
+	// BaseURL is the website to talk to, DefaultBaseURL when empty.
+	BaseURL string `json:"base_url,omitempty"`
+	// CacheDir is where downloaded inputs are kept, a per-user cache
+	// directory when empty.
+	CacheDir string `json:"cache_dir,omitempty"`
+}
+
+// ConfigPath returns the config file location: $AOC_CONFIG or aoc/config.json
+// inside the user's config directory.
+func ConfigPath() (string, error) {
+	if p := os.Getenv(EnvConfig); p != "" {
+		return p, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("client: locating config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc", "config.json"), nil
+}
+
+// LoadConfig reads the config file, if there is one, and applies the
+// environment overrides on top of it.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("client: reading config: %w", err)
+	default:
+		if err := json.Unmarshal(content, cfg); err != nil {
+			return nil, fmt.Errorf("client: parsing config %s: %w", path, err)
+		}
+	}
+
+	if v := os.Getenv(EnvSession); v != "" {
+		cfg.Session = v
+	}
+
+	if v := os.Getenv(EnvAccount); v != "" {
+		cfg.Account = v
+	}
+
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		cfg.BaseURL = v
+	}
+
+	if v := os.Getenv(EnvCacheDir); v != "" {
+		cfg.CacheDir = v
+	}
+
+	return cfg, nil
+}
+
+// AccountKey returns the name inputs of this account are cached under. It
+// fails when Account could not be used as a single directory name and would
+// put the cache outside the cache root.
+func (c *Config) AccountKey() (string, error) {
+	if c.Account != "" {
+		if strings.TrimSpace(c.Account) == "" || c.Account == "." || c.Account == ".." || strings.ContainsAny(c.Account, `/\`) {
+			return "", fmt.Errorf("client: account %q cannot name a cache directory", c.Account)
+		}
+
+		return c.Account, nil
+	}
+
+	if c.Session == "" {
+		return "anonymous", nil
+	}
+
+	// never put the session itself on disk outside the config file.
+	sum := sha256.Sum256([]byte(c.Session))
+	return "session-" + hex.EncodeToString(sum[:6]), nil
+}
+
+// CacheRoot returns the root of the on-disk cache, shared by downloaded
+// inputs, submitted answers and the runner's answer cache.
+func (c *Config) CacheRoot() (string, error) {
+	if c.CacheDir != "" {
+		return c.CacheDir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("client: locating cache directory: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc"), nil
+}
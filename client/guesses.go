@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// GuessError is returned when the guess history shows that submitting an
+// answer is pointless or not allowed yet.
+type GuessError struct {
+	Part   int
+	Answer string
+	Reason string
+}
+
+func (e *GuessError) Error() string {
+	return fmt.Sprintf("client: not submitting %q for part %d: %s", e.Answer, e.Part, e.Reason)
+}
+
+// guess is one submitted answer and what the website said about it.
+type guess struct {
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	At      time.Time `json:"at"`
+}
+
+// history is the guess log of a single day, stored as JSON.
+type history struct {
+	Guesses   []guess   `json:"guesses"`
+	WaitUntil time.Time `json:"wait_until,omitempty"`
+}
+
+func (c *Client) historyPath(year, day int) (string, error) {
+	dir, err := c.cfg.CacheRoot()
+	if err != nil {
+		return "", err
+	}
+
+	account, err := c.cfg.AccountKey()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "submissions", account, fmt.Sprint(year), fmt.Sprintf("day%02d.json", day)), nil
+}
+
+func loadHistory(path string) (*history, error) {
+	h := &history{}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("client: reading guess history: %w", err)
+	}
+
+	if err := json.Unmarshal(content, h); err != nil {
+		return nil, fmt.Errorf("client: parsing guess history %s: %w", path, err)
+	}
+
+	return h, nil
+}
+
+func (h *history) save(path string) error {
+	content, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, append(content, '\n'))
+}
+
+// check returns a *GuessError if answer should not be sent at now.
+func (h *history) check(part int, answer string, now time.Time) error {
+	if now.Before(h.WaitUntil) {
+		return &GuessError{Part: part, Answer: answer, Reason: fmt.Sprintf("the website asked to wait until %s", h.WaitUntil.Format("15:04:05"))}
+	}
+
+	value, numeric := strconv.Atoi(answer)
+	for _, g := range h.Guesses {
+		if g.Part != part {
+			continue
+		}
+
+		if g.Verdict == Correct {
+			return &GuessError{Part: part, Answer: answer, Reason: fmt.Sprintf("part %d was already solved with %q", part, g.Answer)}
+		}
+
+		if g.Answer == answer && g.Verdict.Wrong() {
+			return &GuessError{Part: part, Answer: answer, Reason: "it was already submitted and is " + string(g.Verdict)}
+		}
+
+		// a bound learned from an earlier guess rules out every answer beyond it.
+		bound, err := strconv.Atoi(g.Answer)
+		if numeric != nil || err != nil {
+			continue
+		}
+
+		if g.Verdict == TooHigh && value >= bound {
+			return &GuessError{Part: part, Answer: answer, Reason: fmt.Sprintf("%d was already too high", bound)}
+		}
+
+		if g.Verdict == TooLow && value <= bound {
+			return &GuessError{Part: part, Answer: answer, Reason: fmt.Sprintf("%d was already too low", bound)}
+		}
+	}
+
+	return nil
+}
+
+// record remembers the reply to answer.
+func (h *history) record(part int, answer string, resp *Response, now time.Time) {
+	if resp.Wait > 0 {
+		h.WaitUntil = now.Add(resp.Wait)
+	}
+
+	// a rate limited submission was never judged.
+	if resp.Verdict == RateLimited {
+		return
+	}
+
+	h.Guesses = append(h.Guesses, guess{Part: part, Answer: answer, Verdict: resp.Verdict, At: now})
+}
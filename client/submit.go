@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the website's judgement of a submitted answer.
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	Incorrect     Verdict = "incorrect"
+	TooHigh       Verdict = "too high"
+	TooLow        Verdict = "too low"
+	RateLimited   Verdict = "rate limited"
+	AlreadySolved Verdict = "already solved"
+	Unknown       Verdict = "unknown"
+)
+
+// Wrong reports whether the verdict rules the answer out.
+func (v Verdict) Wrong() bool {
+	return v == Incorrect || v == TooHigh || v == TooLow
+}
+
+// Response is the parsed reply to a submitted answer.
+type Response struct {
+	Verdict Verdict
+	// Wait is how long the website wants us to wait before the next
+	// submission, zero when it did not say.
+	Wait time.Duration
+	// Message is the text of the reply with the markup removed.
+	Message string
+}
+
+var (
+	articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex     = regexp.MustCompile(`<[^>]+>`)
+	leftRegex    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	waitRegex    = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseResponse reads the verdict out of the HTML page returned for a submission.
+func ParseResponse(page string) Response {
+	text := page
+	if m := articleRegex.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+
+	text = strings.Join(strings.Fields(html.UnescapeString(tagRegex.ReplaceAllString(text, ""))), " ")
+	resp := Response{Verdict: Unknown, Message: text, Wait: parseWait(text)}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		resp.Verdict = Correct
+	case strings.Contains(text, "You gave an answer too recently"):
+		resp.Verdict = RateLimited
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		resp.Verdict = AlreadySolved
+	case strings.Contains(text, "That's not the right answer"):
+		switch {
+		case strings.Contains(text, "your answer is too high"):
+			resp.Verdict = TooHigh
+		case strings.Contains(text, "your answer is too low"):
+			resp.Verdict = TooLow
+		default:
+			resp.Verdict = Incorrect
+		}
+	}
+
+	return resp
+}
+
+// parseWait finds "You have 1m 5s left to wait" or "please wait 5 minutes".
+func parseWait(text string) time.Duration {
+	if m := leftRegex.FindStringSubmatch(text); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	if m := waitRegex.FindStringSubmatch(text); m != nil {
+		if m[1] == "one" {
+			return time.Minute
+		}
+
+		minutes, _ := strconv.Atoi(m[1])
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return 0
+}
+
+// Submit posts answer for the given part and returns the website's reply.
+// It does not consult the local guess history; see SubmitAnswer.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (*Response, error) {
+	if c.cfg.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	u := fmt.Sprintf("%s/%d/day/%d/answer", c.baseURL(), year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("client: reading reply: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("client: submitting day %d part %d: %s: %s", day, part, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	r := ParseResponse(string(body))
+
+	return &r, nil
+}
+
+// SubmitAnswer submits answer unless the local guess history already rules
+// it out or the website asked us to wait, and records the verdict.
+func (c *Client) SubmitAnswer(ctx context.Context, year, day, part int, answer string) (*Response, error) {
+	path, err := c.historyPath(year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := loadHistory(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.check(part, answer, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Submit(ctx, year, day, part, answer)
+	if err != nil {
+		return nil, err
+	}
+
+	h.record(part, answer, resp, time.Now())
+	if err := h.save(path); err != nil {
+		return resp, fmt.Errorf("client: saving guess history: %w", err)
+	}
+
+	return resp, nil
+}
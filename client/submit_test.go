@@ -0,0 +1,182 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/client"
+)
+
+// page wraps a reply the way the website does.
+func page(text string) string {
+	return "<html><body><main>\n<article><p>" + text + "</p></article>\n</main></body></html>"
+}
+
+const (
+	right   = `That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations. <a href="/2023/day/7#part2">[Continue to Part Two]</a>`
+	wrong   = `That's not the right answer.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2023/day/7">[Return to Day 7]</a>`
+	tooHigh = `That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.`
+	tooLow  = `That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.`
+	tooSoon = `You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.`
+	solved  = `You don't seem to be solving the right level.  Did you already complete it?`
+	garbled = `Something we never expected &amp; cannot read.`
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		verdict client.Verdict
+		wait    time.Duration
+	}{
+		{"right", right, client.Correct, 0},
+		{"wrong", wrong, client.Incorrect, time.Minute},
+		{"too high", tooHigh, client.TooHigh, time.Minute},
+		{"too low", tooLow, client.TooLow, 5 * time.Minute},
+		{"rate limited", tooSoon, client.RateLimited, time.Minute + 5*time.Second},
+		{"already solved", solved, client.AlreadySolved, 0},
+		{"unknown", garbled, client.Unknown, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := client.ParseResponse(page(tt.text))
+			if resp.Verdict != tt.verdict || resp.Wait != tt.wait {
+				t.Errorf("ParseResponse = %q waiting %v, want %q waiting %v (message %q)", resp.Verdict, resp.Wait, tt.verdict, tt.wait, resp.Message)
+			}
+		})
+	}
+}
+
+func TestVerdictWrong(t *testing.T) {
+	for v, want := range map[client.Verdict]bool{
+		client.Correct:       false,
+		client.Incorrect:     true,
+		client.TooHigh:       true,
+		client.TooLow:        true,
+		client.RateLimited:   false,
+		client.AlreadySolved: false,
+		client.Unknown:       false,
+	} {
+		if got := v.Wrong(); got != want {
+			t.Errorf("%q.Wrong() = %t, want %t", v, got, want)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/7/answer" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.FormValue("level") != "1" || r.FormValue("answer") != "6440" {
+			t.Errorf("form = %v, want level 1 and answer 6440", r.Form)
+		}
+
+		fmt.Fprint(w, page(right))
+	})
+
+	resp, err := c.Submit(context.Background(), 2023, 7, 1, "6440")
+	if err != nil || resp.Verdict != client.Correct {
+		t.Fatalf("Submit = %+v, %v, want %q", resp, err, client.Correct)
+	}
+
+	if _, err := c.Submit(context.Background(), 2023, 8, 1, "6440"); err == nil {
+		t.Errorf("Submit to a missing day succeeded")
+	}
+}
+
+// judge is a website that answers each submitted answer with its reply in
+// replies, or an incorrect verdict without a wait, and counts submissions.
+type judge struct {
+	replies     map[string]string
+	submissions int
+}
+
+func (j *judge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	j.submissions++
+
+	reply, ok := j.replies[r.FormValue("answer")]
+	if !ok {
+		reply = "That's not the right answer."
+	}
+
+	fmt.Fprint(w, page(reply))
+}
+
+func TestSubmitAnswerRefusesKnownGuesses(t *testing.T) {
+	j := &judge{replies: map[string]string{
+		"100": "That's not the right answer; your answer is too high.",
+		"10":  "That's not the right answer; your answer is too low.",
+		"42":  right,
+	}}
+	c := newServer(t, j.ServeHTTP)
+
+	tests := []struct {
+		answer  string
+		verdict client.Verdict // empty when the answer must not be sent
+	}{
+		{"100", client.TooHigh},
+		{"100", ""}, // already too high
+		{"150", ""}, // above a known upper bound
+		{"10", client.TooLow},
+		{"5", ""}, // below a known lower bound
+		{"50", client.Incorrect},
+		{"50", ""}, // already incorrect
+		{"forty", client.Incorrect},
+		{"forty", ""},
+		{"42", client.Correct},
+		{"43", ""}, // already solved
+	}
+
+	for _, tt := range tests {
+		before := j.submissions
+		resp, err := c.SubmitAnswer(context.Background(), 2023, 7, 1, tt.answer)
+		sent := j.submissions > before
+
+		if tt.verdict == "" {
+			var guessErr *client.GuessError
+			if !errors.As(err, &guessErr) || sent {
+				t.Errorf("SubmitAnswer(%s) = %+v, %v, sent %t, want a *GuessError before sending", tt.answer, resp, err, sent)
+			}
+
+			continue
+		}
+
+		if err != nil || !sent || resp.Verdict != tt.verdict {
+			t.Errorf("SubmitAnswer(%s) = %+v, %v, sent %t, want %q", tt.answer, resp, err, sent, tt.verdict)
+		}
+	}
+
+	// the history is per part: part two starts afresh.
+	if resp, err := c.SubmitAnswer(context.Background(), 2023, 7, 2, "100"); err != nil || resp.Verdict != client.TooHigh {
+		t.Errorf("SubmitAnswer of part 2 = %+v, %v, want %q", resp, err, client.TooHigh)
+	}
+}
+
+func TestSubmitAnswerWaits(t *testing.T) {
+	j := &judge{replies: map[string]string{"7": tooSoon}}
+	c := newServer(t, j.ServeHTTP)
+
+	resp, err := c.SubmitAnswer(context.Background(), 2023, 7, 1, "7")
+	if err != nil || resp.Verdict != client.RateLimited {
+		t.Fatalf("SubmitAnswer = %+v, %v, want %q", resp, err, client.RateLimited)
+	}
+
+	// the wait the website asked for holds every answer back, even one that
+	// was never judged.
+	var guessErr *client.GuessError
+	if _, err := c.SubmitAnswer(context.Background(), 2023, 7, 1, "7"); !errors.As(err, &guessErr) {
+		t.Errorf("SubmitAnswer while waiting: error = %v, want a *GuessError", err)
+	}
+
+	if j.submissions != 1 {
+		t.Errorf("the website got %d submissions, want 1", j.submissions)
+	}
+}
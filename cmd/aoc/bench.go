@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/bench"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to benchmark")
+	all := fs.Bool("all", false, "benchmark every registered day")
+	in := fs.String("input", "", "puzzle input: a file or a directory of *.txt files (default: the day's input.txt, fetched when missing)")
+	runs := fs.Int("n", 10, "measured runs per input")
+	warmup := fs.Int("warmup", 1, "unmeasured runs before measuring")
+	format := fs.String("format", "text", "report format: text, json or csv")
+	out := fs.String("o", "", "write the report to this file instead of stdout")
+	budget := fs.Duration("budget", time.Second, "fail when a median total time exceeds this, 0 to disable")
+	impl := fs.String("impl", bench.Main, "comma-separated implementations to measure: main, the names of a day's alternates, or all")
+	fs.Parse(args)
+
+	if *format != "text" && *format != "json" && *format != "csv" {
+		return fmt.Errorf("bench: unknown format %q, want text, json or csv", *format)
+	}
+
+	days, err := selectDays("bench", *day, *all, *in)
+	if err != nil {
+		return err
+	}
+
+	var results []bench.Result
+	failed := false
+	for _, d := range days {
+		path := *in
+		if path == "" {
+			if path, err = dayInput(d); err != nil {
+				fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, err)
+				failed = true
+				continue
+			}
+		}
+
+		sources, err := input.Resolve(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, err)
+			failed = true
+			continue
+		}
+
+		impls := implementations(d, *impl)
+		if len(impls) == 0 && *all {
+			continue
+		} else if len(impls) == 0 {
+			fmt.Fprintf(os.Stderr, "day %d: no implementation in %q\n", d.Day, *impl)
+			failed = true
+			continue
+		}
+
+		for _, src := range sources {
+			content, err := src.Read()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, parse.InFile(err, src.Name))
+				failed = true
+				continue
+			}
+
+			for _, name := range impls {
+				res, err := bench.Run(d, name, src.Name, content, *runs, *warmup)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "day %d: %s: %v\n", d.Day, name, parse.InFile(err, src.Name))
+					failed = true
+					continue
+				}
+
+				results = append(results, res)
+			}
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	report := bench.NewReport(results)
+	switch *format {
+	case "json":
+		err = report.WriteJSON(w)
+	case "csv":
+		err = report.WriteCSV(w)
+	default:
+		err = report.WriteText(w, *budget)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("some days failed")
+	}
+
+	if *budget > 0 {
+		for _, res := range results {
+			if res.Phase(bench.Total).Median > *budget {
+				return fmt.Errorf("day %d is over the %v budget", res.Day, *budget)
+			}
+		}
+	}
+
+	return nil
+}
+
+// implementations returns the implementations of d named by the -impl flag:
+// the names it lists that d has, or all of them for "all". With -all a name
+// may only exist for some days.
+func implementations(d aoc.Day, flag string) []string {
+	names := []string{bench.Main}
+	for _, a := range d.Alternates {
+		names = append(names, a.Name)
+	}
+
+	if flag == "all" {
+		return names
+	}
+
+	var out []string
+	for _, name := range strings.Split(flag, ",") {
+		if name = strings.TrimSpace(name); slices.Contains(names, name) {
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
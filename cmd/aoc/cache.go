@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/cache"
+	"github.com/unkn0wn-root/advent_of_code_2023/client"
+)
+
+// openCache opens the answer cache in the on-disk cache of the client
+// config, next to the downloaded inputs.
+func openCache() (*cache.Cache, error) {
+	cfg, err := client.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := cfg.CacheRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.Open(root), nil
+}
+
+func cacheCmd(args []string) error {
+	if len(args) == 0 || args[0] != "clear" {
+		return errors.New("cache: usage: aoc cache clear [-day N]")
+	}
+
+	fs := flag.NewFlagSet("cache clear", flag.ExitOnError)
+	day := fs.Int("day", 0, "only clear the answers of this day")
+	fs.Parse(args[1:])
+
+	c, err := openCache()
+	if err != nil {
+		return err
+	}
+
+	if err := c.Clear(*day); err != nil {
+		return err
+	}
+
+	if *day != 0 {
+		fmt.Printf("cleared day %d from %s\n", *day, c.Dir())
+	} else {
+		fmt.Println("cleared", c.Dir())
+	}
+
+	return nil
+}
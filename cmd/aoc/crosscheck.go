@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/bench"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+const (
+	statusDiffers = "DIFFERS"
+	statusTimeout = "timeout"
+)
+
+// crosscheck solves every input of the days that have alternates with the
+// day's own solver and with each alternate, bypassing the answer cache, and
+// prints one table row per implementation and part. Disagreements are also
+// reported on stderr, naming the input. Alternates that run out of time are
+// reported but are not failures.
+func (r *runner) crosscheck(days []aoc.Day) error {
+	days = slices.DeleteFunc(slices.Clone(days), func(d aoc.Day) bool { return len(d.Alternates) == 0 })
+	if len(days) == 0 {
+		return errors.New("crosscheck: no selected day has alternate implementations")
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tINPUT\tPART\tIMPLEMENTATION\tANSWER\tTIME\tSTATUS")
+
+	ok := true
+	for _, d := range days {
+		if !r.crosscheckDay(tw, d) {
+			ok = false
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("crosscheck: some implementations disagree or failed")
+	}
+
+	return nil
+}
+
+// crosscheckDay cross-checks every input of d. It returns false if any
+// implementation disagreed or failed.
+func (r *runner) crosscheckDay(tw *tabwriter.Writer, d aoc.Day) bool {
+	path := r.input
+	if path == "" {
+		var err error
+		if path, err = dayInput(d); err != nil {
+			fmt.Fprintf(tw, "%d\t-\t-\t-\t-\t-\t%s: %v\n", d.Day, statusError, err)
+			return false
+		}
+	}
+
+	sources, err := input.Resolve(path)
+	if err != nil {
+		fmt.Fprintf(tw, "%d\t-\t-\t-\t-\t-\t%s: %v\n", d.Day, statusError, err)
+		return false
+	}
+
+	ok := true
+	for _, src := range sources {
+		content, err := src.Read()
+		if err != nil {
+			fmt.Fprintf(tw, "%d\t%s\t-\t-\t-\t-\t%s: %v\n", d.Day, src.Name, statusError, err)
+			ok = false
+			continue
+		}
+
+		if !r.crosscheckInput(tw, d, src.Name, content) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// crosscheckInput solves one input with every implementation of d and
+// compares their answers to those of the day's own solver.
+func (r *runner) crosscheckInput(tw *tabwriter.Writer, d aoc.Day, name, content string) bool {
+	impls := append([]aoc.Alternate{{Name: bench.Main, Parts: []int{1, 2}, New: d.New}}, d.Alternates...)
+
+	// want holds the answers of the day's own solver, by part.
+	want := make(map[int]string)
+	ok := true
+	for _, impl := range impls {
+		var parts []int
+		for _, part := range r.parts {
+			if slices.Contains(impl.Parts, part) {
+				parts = append(parts, part)
+			}
+		}
+
+		if len(parts) == 0 {
+			continue
+		}
+
+		results, err := r.solveWith(d, impl, name, content, parts)
+		if err != nil && len(results) == 0 {
+			status := fmt.Sprintf("%s: %v", statusError, parseError(name, err))
+			if errors.As(err, new(*aoc.TimeoutError)) {
+				status = fmt.Sprintf("%s: parse %v", statusTimeout, err)
+			} else {
+				ok = false
+			}
+
+			fmt.Fprintf(tw, "%d\t%s\t-\t%s\t-\t-\t%s\n", d.Day, name, impl.Name, status)
+			continue
+		}
+
+		for _, res := range results {
+			answer, status := res.Answer, "ok"
+			if res.Err != nil {
+				answer = "-"
+			}
+
+			switch expected, known := want[res.Part]; {
+			case errors.As(res.Err, new(*aoc.TimeoutError)):
+				status = fmt.Sprintf("%s: %v", statusTimeout, res.Err)
+			case res.Err != nil:
+				status = fmt.Sprintf("%s: %v", statusError, res.Err)
+				ok = false
+			case impl.Name == bench.Main:
+				want[res.Part] = res.Answer
+			case !known:
+				status = "unconfirmed"
+			case res.Answer != expected:
+				status = statusDiffers
+				ok = false
+				fmt.Fprintf(os.Stderr, "day %d: %s: part %d: %s gives %s, %s gives %s\n",
+					d.Day, name, res.Part, impl.Name, res.Answer, bench.Main, expected)
+			}
+
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%v\t%s\n", d.Day, name, res.Part, impl.Name, answer, bench.Round(res.Duration), status)
+		}
+	}
+
+	return ok
+}
+
+// solveWith solves parts of one input with impl, giving it r.timeout, if
+// not zero.
+func (r *runner) solveWith(d aoc.Day, impl aoc.Alternate, name, content string, parts []int) ([]aoc.Result, error) {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	d.New = impl.New
+
+	return aoc.Run(ctx, d, name, content, parts)
+}
@@ -0,0 +1,17 @@
+package main
+
+// every Go day registers itself with the aoc package when imported.
+import (
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_2/cubes"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_3/gears"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_4/scratchcards"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_5/almanac"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day_9/mirage"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__10/pipes"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__11/galaxies"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__16/lava"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__17/crucible"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__18/lagoon"
+	_ "github.com/unkn0wn-root/advent_of_code_2023/day__19/aplenty"
+)
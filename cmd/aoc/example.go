@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+func exampleCmd(args []string) error {
+	fs := flag.NewFlagSet("example", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to check")
+	all := fs.Bool("all", false, "check every registered day")
+	fs.Parse(args)
+
+	days, err := selectDays("example", *day, *all, "")
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tEXAMPLE\tPART\tEXPECTED\tGOT\tSTATUS")
+
+	failed := false
+	for _, d := range days {
+		if !exampleDay(tw, d) {
+			failed = true
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("example: some answers are wrong or could not be computed")
+	}
+
+	return nil
+}
+
+// exampleDay solves the parts each embedded example of d has an expected
+// answer for and writes one table row per part. It returns false if any
+// answer did not match or could not be computed.
+func exampleDay(tw *tabwriter.Writer, d aoc.Day) bool {
+	examples, err := aoc.LoadExamples(d)
+	if err != nil {
+		fmt.Fprintf(tw, "%d\t-\t-\t-\t-\t%s: %v\n", d.Day, statusError, err)
+		return false
+	}
+
+	if len(examples) == 0 {
+		fmt.Fprintf(tw, "%d\t-\t-\t-\t-\t%s\n", d.Day, statusMissing)
+		return true
+	}
+
+	ok := true
+	for _, ex := range examples {
+		var parts []int
+		for _, part := range []int{1, 2} {
+			if ex.Answer.Part(part) != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		// each part is solved on its own so that one failing part does not
+		// take the verdict of the others with it.
+		for _, part := range parts {
+			expected := ex.Answer.Part(part)
+
+			got, err := solveContent(context.Background(), d, ex.Name, ex.Input, []int{part})
+			switch {
+			case err != nil:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t-\t%s: %v\n", d.Day, ex.Name, part, expected, statusError, err)
+				ok = false
+			case got[0] == expected:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", d.Day, ex.Name, part, expected, got[0], statusPass)
+			default:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", d.Day, ex.Name, part, expected, got[0], statusFail)
+				ok = false
+			}
+		}
+	}
+
+	return ok
+}
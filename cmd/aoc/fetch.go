@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/client"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+func fetchCmd(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	year := fs.Int("year", aoc.Year, "event year")
+	day := fs.Int("day", 0, "day to fetch (1-25)")
+	out := fs.String("o", "", "also copy the input to this file, e.g. day_1/input.txt")
+	fs.Parse(args)
+
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("fetch: invalid day %d, want 1-25", *day)
+	}
+
+	cfg, err := client.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	path, err := client.New(cfg).InputFile(context.Background(), *year, *day)
+	if err != nil {
+		return err
+	}
+
+	if *out != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(*out, content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(path)
+
+	return nil
+}
+
+// dayInput returns the input path of d: its input.txt when present and the
+// cached download, fetched on first use, otherwise.
+func dayInput(d aoc.Day) (string, error) {
+	path := filepath.Join(d.Dir, input.DefaultFile)
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return path, nil
+	}
+
+	cfg, err := client.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+
+	cached, err := client.New(cfg).InputFile(context.Background(), aoc.Year, d.Day)
+	if err != nil {
+		return "", fmt.Errorf("%s is missing and fetching it failed: %w", path, err)
+	}
+
+	return cached, nil
+}
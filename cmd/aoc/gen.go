@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+func genCmd(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to generate an input for")
+	all := fs.Bool("all", false, "generate an input for every day that has a generator")
+	seed := fs.Int64("seed", 1, "random seed; the same seed, scale and day always give the same input")
+	scale := fs.Float64("scale", 1, "size of the input relative to a real one")
+	out := fs.String("o", "", "output file, stdout when omitted; with -all, the directory to write <day dir>/input.txt files under")
+	fs.Parse(args)
+
+	if *scale <= 0 {
+		return fmt.Errorf("gen: invalid scale %v, want more than 0", *scale)
+	}
+
+	days, err := selectDays("gen", *day, *all, "")
+	if err != nil {
+		return err
+	}
+
+	if !*all {
+		d := days[0]
+		if d.Generate == nil {
+			return fmt.Errorf("gen: day %d has no generator", d.Day)
+		}
+
+		if *out == "" {
+			return generate(os.Stdout, d, *seed, *scale)
+		}
+
+		return generateFile(*out, d, *seed, *scale)
+	}
+
+	if *out == "" {
+		return errors.New("gen: -all requires -o directory")
+	}
+
+	for _, d := range days {
+		if d.Generate == nil {
+			continue
+		}
+
+		path := filepath.Join(*out, d.Dir, input.DefaultFile)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		if err := generateFile(path, d, *seed, *scale); err != nil {
+			return err
+		}
+
+		fmt.Println("wrote", path)
+	}
+
+	return nil
+}
+
+// generateFile writes an input for d, from seed and at scale, to path.
+func generateFile(path string, d aoc.Day, seed int64, scale float64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := generate(f, d, seed, scale); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func generate(w io.Writer, d aoc.Day, seed int64, scale float64) error {
+	bw := bufio.NewWriter(w)
+	if err := d.Generate(bw, rand.New(rand.NewSource(seed)), scale); err != nil {
+		return fmt.Errorf("gen: day %d: %w", d.Day, err)
+	}
+
+	return bw.Flush()
+}
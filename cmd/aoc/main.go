@@ -0,0 +1,70 @@
+// Command aoc runs the registered Go solutions of Advent of Code 2023.
+//
+// Usage:
+//
+//	aoc run -day N [-part 1|2] [-input path] [-no-cache]
+//	aoc run -day N [-part 1|2] [-cpuprofile file] [-memprofile file] [-trace file]
+//	aoc run -all
+//	aoc run -day N | -all -crosscheck [-timeout d]
+//	aoc cache clear [-day N]
+//	aoc gen -day N [-seed S] [-scale X] [-o file]
+//	aoc gen -all [-seed S] [-scale X] -o dir
+//	aoc fetch [-year 2023] -day N [-o file]
+//	aoc submit -day N -part 1|2 [-input path]
+//	aoc verify [-day N] [-record]
+//	aoc example -day N | -all
+//	aoc bench -day N | -all [-n runs] [-format text|json|csv] [-o file] [-budget 1s]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{name: "run", usage: "run -day N [-part 1|2] [-input path] | run -all", run: runCmd},
+	{name: "cache", usage: "cache clear [-day N]", run: cacheCmd},
+	{name: "gen", usage: "gen -day N [-seed S] [-scale X] [-o file] | gen -all -o dir", run: genCmd},
+	{name: "fetch", usage: "fetch [-year 2023] -day N [-o file]", run: fetchCmd},
+	{name: "submit", usage: "submit -day N -part 1|2 [-input path]", run: submitCmd},
+	{name: "verify", usage: "verify [-day N] [-record]", run: verifyCmd},
+	{name: "example", usage: "example -day N | -all", run: exampleCmd},
+	{name: "bench", usage: "bench -day N | -all [-n runs] [-format text|json|csv] [-o file] [-budget 1s]", run: benchCmd},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "aoc:", err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
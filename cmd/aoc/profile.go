@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profiler writes the profiles asked for by the -cpuprofile, -memprofile and
+// -trace flags. An empty path disables that profile.
+type profiler struct {
+	cpu, mem, trace string
+
+	cpuFile, traceFile *os.File
+}
+
+// enabled reports whether any profile was asked for.
+func (p *profiler) enabled() bool {
+	return p.cpu != "" || p.mem != "" || p.trace != ""
+}
+
+// start starts the CPU profile and the execution trace.
+func (p *profiler) start() error {
+	if p.cpu != "" {
+		f, err := os.Create(p.cpu)
+		if err != nil {
+			return fmt.Errorf("cpuprofile: %w", err)
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return fmt.Errorf("cpuprofile: %w", err)
+		}
+
+		p.cpuFile = f
+	}
+
+	if p.trace != "" {
+		f, err := os.Create(p.trace)
+		if err != nil {
+			p.stop()
+			return fmt.Errorf("trace: %w", err)
+		}
+
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			p.stop()
+			return fmt.Errorf("trace: %w", err)
+		}
+
+		p.traceFile = f
+	}
+
+	return nil
+}
+
+// stop stops the CPU profile and the execution trace and writes the heap
+// profile, after a garbage collection so that it is up to date.
+func (p *profiler) stop() error {
+	var errs []error
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpuFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("cpuprofile: %w", err))
+		}
+
+		p.cpuFile = nil
+	}
+
+	if p.traceFile != nil {
+		trace.Stop()
+		if err := p.traceFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("trace: %w", err))
+		}
+
+		p.traceFile = nil
+	}
+
+	if p.mem != "" {
+		if err := writeHeapProfile(p.mem); err != nil {
+			errs = append(errs, fmt.Errorf("memprofile: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	runtime.GC()
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
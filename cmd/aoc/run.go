@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/bench"
+	"github.com/unkn0wn-root/advent_of_code_2023/cache"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// runner holds the settings of an aoc run.
+type runner struct {
+	parts   []int
+	timeout time.Duration
+	// input is the -input flag; empty means each day's own input.
+	input string
+	// out receives the results in a machine-readable format; nil prints text.
+	out *output.Writer
+	// cache holds the answers of earlier runs, nil when it is unavailable.
+	// With refresh set cached answers are not used, only replaced.
+	cache   *cache.Cache
+	refresh bool
+}
+
+// dayRun is the outcome of running one day.
+type dayRun struct {
+	day    aoc.Day
+	inputs []sourceRun
+	// err is set when the day's inputs could not be found.
+	err error
+}
+
+// sourceRun is the outcome of running one input: the results of the parts
+// that were attempted, and the error that stopped it, already naming the
+// input.
+type sourceRun struct {
+	name    string
+	results []aoc.Result
+	err     error
+}
+
+func (run dayRun) failed() bool {
+	if run.err != nil {
+		return true
+	}
+
+	for _, in := range run.inputs {
+		if in.err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to run")
+	part := fs.Int("part", 0, "part to run (1 or 2), both when omitted")
+	all := fs.Bool("all", false, "run every registered day, in parallel, and print a summary table")
+	in := fs.String("input", "", "puzzle input: a file, - for stdin, or a directory of *.txt files (default: the day's input.txt, fetched when missing)")
+	timeout := fs.Duration("timeout", 0, "give up on an input after this long, 0 for no limit")
+	format := fs.String("format", output.Text, "output format: text, json or tsv")
+	noCache := fs.Bool("no-cache", false, "solve even when the answer is cached, and cache the fresh answer")
+	crosscheck := fs.Bool("crosscheck", false, "solve with every alternate implementation too and report any disagreement")
+	prof := &profiler{}
+	fs.StringVar(&prof.cpu, "cpuprofile", "", "write a CPU profile of the solvers to `file`")
+	fs.StringVar(&prof.mem, "memprofile", "", "write a heap profile to `file` once the solvers are done")
+	fs.StringVar(&prof.trace, "trace", "", "write an execution trace of the solvers to `file`")
+	fs.Parse(args)
+
+	if *part != 0 && *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part %d, want 1 or 2", *part)
+	}
+
+	if err := output.Check(*format); err != nil {
+		return err
+	}
+
+	days, err := selectDays("run", *day, *all, *in)
+	if err != nil {
+		return err
+	}
+
+	// A profile of answers read from the cache would be empty.
+	r := &runner{parts: []int{1, 2}, timeout: *timeout, input: *in, refresh: *noCache || prof.enabled()}
+	if *part != 0 {
+		r.parts = []int{*part}
+	}
+
+	if r.cache, err = openCache(); err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: answer cache disabled: %v\n", err)
+	}
+
+	if *format != output.Text {
+		if r.out, err = output.NewWriter(os.Stdout, *format); err != nil {
+			return err
+		}
+	}
+
+	if *crosscheck {
+		if *format != output.Text {
+			return errors.New("run: -crosscheck only prints text")
+		}
+
+		if err := prof.start(); err != nil {
+			return err
+		}
+
+		err := r.crosscheck(days)
+		if perr := prof.stop(); err == nil {
+			err = perr
+		}
+
+		return err
+	}
+
+	if err := prof.start(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	runs := r.runDays(days)
+	wall := time.Since(start)
+
+	if err := prof.stop(); err != nil {
+		return err
+	}
+
+	failed := false
+	for _, run := range runs {
+		if run.failed() {
+			failed = true
+		}
+	}
+
+	switch {
+	case r.out != nil:
+		if err := r.write(runs); err != nil {
+			return err
+		}
+	case len(runs) == 1:
+		printDay(runs[0])
+	default:
+		if err := printSummary(runs, wall); err != nil {
+			return err
+		}
+	}
+
+	if failed {
+		return errors.New("some days failed")
+	}
+
+	return nil
+}
+
+// selectDays returns the days named by the -day and -all flags of cmd.
+// An explicit input only makes sense for a single day.
+func selectDays(cmd string, day int, all bool, in string) ([]aoc.Day, error) {
+	switch {
+	case all:
+		if in != "" {
+			return nil, fmt.Errorf("%s: -input can only be used with -day", cmd)
+		}
+
+		return aoc.Days(), nil
+	case day != 0:
+		d, ok := aoc.Lookup(day)
+		if !ok {
+			return nil, fmt.Errorf("day %d is not registered", day)
+		}
+
+		return []aoc.Day{d}, nil
+	default:
+		return nil, fmt.Errorf("%s: either -day or -all is required", cmd)
+	}
+}
+
+// runDays runs days on a pool of GOMAXPROCS workers and returns their
+// outcomes in the order of days. A panic in one day fails that day only.
+func (r *runner) runDays(days []aoc.Day) []dayRun {
+	runs := make([]dayRun, len(days))
+	jobs := make(chan int)
+
+	workers := min(runtime.GOMAXPROCS(0), len(days))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i] = r.runDay(days[i])
+			}
+		}()
+	}
+
+	for i := range days {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return runs
+}
+
+// runDay solves the requested parts for every input of d: the inputs named
+// by r.input, or the day's own input.
+func (r *runner) runDay(d aoc.Day) (run dayRun) {
+	run.day = d
+	defer func() {
+		if v := recover(); v != nil {
+			run.err = &aoc.PanicError{Value: v}
+		}
+	}()
+
+	path := r.input
+	if path == "" {
+		if path, run.err = dayInput(d); run.err != nil {
+			return run
+		}
+	}
+
+	sources, err := input.Resolve(path)
+	if err != nil {
+		run.err = err
+		return run
+	}
+
+	for _, src := range sources {
+		results, err := r.runSource(d, src)
+		run.inputs = append(run.inputs, sourceRun{name: src.Name, results: results, err: err})
+	}
+
+	return run
+}
+
+// runSource solves one input, giving it r.timeout, if not zero, for parsing
+// and all its parts. Answers found in the cache are not solved again, and
+// fresh answers are added to it.
+func (r *runner) runSource(d aoc.Day, src input.Source) ([]aoc.Result, error) {
+	content, err := src.Read()
+	if err != nil {
+		return nil, parse.InFile(err, src.Name)
+	}
+
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	hash := aoc.HashInput(content)
+
+	var results []aoc.Result
+	var missing []int
+	for _, part := range r.parts {
+		answer, ok := r.cached(d, hash, part)
+		if !ok {
+			missing = append(missing, part)
+			continue
+		}
+
+		results = append(results, aoc.Result{
+			Year:      aoc.Year,
+			Day:       d.Day,
+			Title:     d.Title,
+			Part:      part,
+			Answer:    answer,
+			Input:     src.Name,
+			InputHash: hash,
+			Cached:    true,
+		})
+	}
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	fresh, err := aoc.Run(ctx, d, src.Name, content, missing)
+	if err != nil {
+		if len(fresh) == 0 {
+			err = parseError(src.Name, err)
+			fresh = output.Failed(d, src.Name, content, missing, err)
+		} else {
+			err = parse.InFile(err, src.Name)
+		}
+	}
+
+	for _, res := range fresh {
+		if res.Err == nil && r.cache != nil {
+			if err := r.cache.Put(d, hash, res.Part, res.Answer); err != nil {
+				fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, err)
+			}
+		}
+	}
+
+	results = append(results, fresh...)
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Part < results[j].Part })
+
+	return results, err
+}
+
+// cached returns the cached answer to part of d for the input with the given
+// hash, unless the cache is unavailable or being refreshed.
+func (r *runner) cached(d aoc.Day, hash string, part int) (string, bool) {
+	if r.cache == nil || r.refresh {
+		return "", false
+	}
+
+	return r.cache.Get(d, hash, part)
+}
+
+// write records every result to r.out. Errors are also reported on stderr.
+func (r *runner) write(runs []dayRun) error {
+	for _, run := range runs {
+		if run.err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", run.day.Day, run.err)
+		}
+
+		for _, in := range run.inputs {
+			for _, res := range in.results {
+				if err := r.out.Write(res); err != nil {
+					return err
+				}
+			}
+
+			if in.err != nil {
+				fmt.Fprintf(os.Stderr, "day %d: %v\n", run.day.Day, in.err)
+			}
+		}
+	}
+
+	return r.out.Close()
+}
+
+// printDay prints the answers of a single day, labelled with the input when
+// there is more than one. Errors are reported on stderr.
+func printDay(run dayRun) {
+	d := run.day
+	if run.err != nil {
+		fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, run.err)
+		return
+	}
+
+	for _, in := range run.inputs {
+		header := fmt.Sprintf("Day %d: %s", d.Day, d.Title)
+		if len(run.inputs) > 1 {
+			header += " [" + in.name + "]"
+		}
+
+		fmt.Println(header)
+		for _, res := range in.results {
+			switch {
+			case res.Cached:
+				fmt.Printf("  Part %d: %s (cached)\n", res.Part, res.Answer)
+			case res.Err == nil:
+				fmt.Printf("  Part %d: %s\n", res.Part, res.Answer)
+			}
+		}
+
+		if in.err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, in.err)
+		}
+	}
+}
+
+// printSummary prints one table row per part of every day, followed by the
+// wall time of the whole run and the time spent solving.
+func printSummary(runs []dayRun, wall time.Duration) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tTITLE\tPART\tANSWER\tTIME\tSTATUS")
+
+	var solving time.Duration
+	parts := 0
+	for _, run := range runs {
+		d := run.day
+		if run.err != nil {
+			fmt.Fprintf(tw, "%d\t%s\t-\t-\t-\tERROR: %v\n", d.Day, d.Title, run.err)
+			continue
+		}
+
+		for _, in := range run.inputs {
+			if in.err != nil && len(in.results) == 0 {
+				fmt.Fprintf(tw, "%d\t%s\t-\t-\t-\tERROR: %v\n", d.Day, d.Title, in.err)
+			}
+
+			for _, res := range in.results {
+				solving += res.Duration
+				parts++
+
+				switch {
+				case res.Err != nil && res.Duration == 0:
+					fmt.Fprintf(tw, "%d\t%s\t%d\t-\t-\tERROR: %v\n", d.Day, d.Title, res.Part, in.err)
+				case res.Err != nil:
+					fmt.Fprintf(tw, "%d\t%s\t%d\t-\t%v\tERROR: %v\n", d.Day, d.Title, res.Part, bench.Round(res.Duration), in.err)
+				case res.Cached:
+					fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t-\tcached\n", d.Day, d.Title, res.Part, res.Answer)
+				default:
+					fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\tok\n", d.Day, d.Title, res.Part, res.Answer, bench.Round(res.Duration))
+				}
+			}
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d days, %d parts in %v wall time (%v solving)\n", len(runs), parts, bench.Round(wall), bench.Round(solving))
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// solveSource parses src with a fresh solver of d and returns the answers
+// to parts formatted as strings. When a part fails, the answers to the parts
+// before it are returned along with the error.
+func solveSource(ctx context.Context, d aoc.Day, src input.Source, parts []int) ([]string, error) {
+	content, err := src.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return solveContent(ctx, d, src.Name, content, parts)
+}
+
+// solveContent is solveSource for an input already in memory; name is only
+// used in errors.
+func solveContent(ctx context.Context, d aoc.Day, name, content string, parts []int) ([]string, error) {
+	results, err := aoc.Run(ctx, d, name, content, parts)
+	if err != nil && len(results) == 0 {
+		return nil, parseError(name, err)
+	}
+
+	answers := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.Err == nil {
+			answers = append(answers, r.Answer)
+		}
+	}
+
+	return answers, err
+}
+
+// parseError places a failure to parse the input called name.
+func parseError(name string, err error) error {
+	var te *aoc.TimeoutError
+	if errors.As(err, &te) {
+		return fmt.Errorf("%s: parse %w", name, err)
+	}
+
+	return parse.InFile(err, name)
+}
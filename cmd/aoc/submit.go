@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/client"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+func submitCmd(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to submit")
+	part := fs.Int("part", 0, "part to submit (1 or 2)")
+	in := fs.String("input", "", "puzzle input file (default: the day's input.txt, fetched when missing)")
+	fs.Parse(args)
+
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("submit: invalid part %d, want 1 or 2", *part)
+	}
+
+	d, ok := aoc.Lookup(*day)
+	if !ok {
+		return fmt.Errorf("day %d is not registered", *day)
+	}
+
+	path := *in
+	if path == "" {
+		var err error
+		if path, err = dayInput(d); err != nil {
+			return err
+		}
+	}
+
+	answer, err := solvePart(d, path, *part)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := client.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Day %d part %d: submitting %s\n", d.Day, *part, answer)
+
+	resp, err := client.New(cfg).SubmitAnswer(context.Background(), aoc.Year, d.Day, *part, answer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(resp.Message)
+	if resp.Wait > 0 {
+		fmt.Printf("Next submission possible in %s\n", resp.Wait)
+	}
+
+	if resp.Verdict != client.Correct {
+		return fmt.Errorf("answer is %s", resp.Verdict)
+	}
+
+	return nil
+}
+
+// solvePart returns the answer of one part of d for the single input at path.
+func solvePart(d aoc.Day, path string, part int) (string, error) {
+	sources, err := input.Resolve(path)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sources) != 1 {
+		return "", errors.New("a single input file is required")
+	}
+
+	answers, err := solveSource(context.Background(), d, sources[0], []int{part})
+	if err != nil {
+		return "", err
+	}
+
+	return answers[0], nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+const (
+	statusPass    = "pass"
+	statusFail    = "FAIL"
+	statusMissing = "missing"
+	statusError   = "ERROR"
+)
+
+func verifyCmd(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to verify, every registered day when omitted")
+	record := fs.Bool("record", false, "record the computed answer for every part that has no known answer yet")
+	fs.Parse(args)
+
+	days := aoc.Days()
+	if *day != 0 {
+		d, ok := aoc.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+
+		days = []aoc.Day{d}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tINPUT\tPART\tEXPECTED\tGOT\tSTATUS")
+
+	failed := false
+	for _, d := range days {
+		if !verifyDay(tw, d, *record) {
+			failed = true
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("verify: some answers are wrong or could not be computed")
+	}
+
+	return nil
+}
+
+// verifyDay solves every input with recorded answers in the directory of d,
+// plus the day's own input, and writes one table row per part. An input
+// without recorded answers that does not exist and cannot be fetched is
+// reported as missing. It returns false if any answer did not match or could
+// not be computed.
+func verifyDay(tw *tabwriter.Writer, d aoc.Day, record bool) bool {
+	answers, err := aoc.LoadAnswers(d.Dir)
+	if err != nil {
+		fmt.Fprintf(tw, "%d\t%s\t-\t-\t-\t%s: %v\n", d.Day, aoc.AnswersFile, statusError, err)
+		return false
+	}
+
+	names := make([]string, 0, len(answers)+1)
+	for name := range answers {
+		names = append(names, name)
+	}
+
+	if _, ok := answers[input.DefaultFile]; !ok {
+		names = append(names, input.DefaultFile)
+	}
+
+	sort.Strings(names)
+
+	ok, changed := true, false
+	for _, name := range names {
+		known := answers[name]
+
+		got, missing, err := verifyInput(d, name)
+		for _, part := range []int{1, 2} {
+			expected := known.Part(part)
+			if expected == "" {
+				expected = "-"
+			}
+
+			switch {
+			case missing && known == (aoc.Answer{}):
+				fmt.Fprintf(tw, "%d\t%s\t%d\t-\t-\t%s\n", d.Day, name, part, statusMissing)
+			case err != nil:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t-\t%s: %v\n", d.Day, name, part, expected, statusError, err)
+				ok = false
+			case known.Part(part) == "":
+				status := statusMissing
+				if record {
+					known.Set(part, got[part-1])
+					status = "recorded"
+					changed = true
+				}
+
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", d.Day, name, part, expected, got[part-1], status)
+			case known.Part(part) == got[part-1]:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", d.Day, name, part, expected, got[part-1], statusPass)
+			default:
+				fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", d.Day, name, part, expected, got[part-1], statusFail)
+				ok = false
+			}
+		}
+
+		if known != (aoc.Answer{}) {
+			answers[name] = known
+		}
+	}
+
+	if changed {
+		if err := answers.Save(d.Dir); err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", d.Day, err)
+			return false
+		}
+	}
+
+	return ok
+}
+
+// verifyInput solves both parts of the input name, relative to the
+// directory of d. The day's own input is located with dayInput, so a cached
+// download is used when the directory has none. missing reports that the
+// error is only that the input does not exist and could not be fetched.
+func verifyInput(d aoc.Day, name string) (got []string, missing bool, err error) {
+	path := filepath.Join(d.Dir, name)
+	if name == input.DefaultFile {
+		if path, err = dayInput(d); err != nil {
+			return nil, true, err
+		}
+	}
+
+	sources, err := input.Resolve(path)
+	if err != nil {
+		return nil, errors.Is(err, fs.ErrNotExist), err
+	}
+
+	if len(sources) != 1 {
+		return nil, false, fmt.Errorf("%s: a single input file is required", name)
+	}
+
+	got, err = solveSource(context.Background(), d, sources[0], []int{1, 2})
+
+	return got, false, err
+}
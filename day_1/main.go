@@ -1,82 +1,131 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"regexp"
-	"strconv"
-	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
 )
 
-type Solution struct{}
+func main() {
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv; text or csv with -explain")
+	words := flag.String("words", "en", "number words for part two: en, de, es, pl, or a JSON vocabulary file")
+	ignoreCase := flag.Bool("ignore-case", false, "match number words regardless of case")
+	stream := flag.Bool("stream", false, "read the input in chunks on parallel workers instead of all at once")
+	workers := flag.Int("workers", 0, "workers for -stream, 0 for one per CPU")
+	explain := flag.Bool("explain", false, "show the digits part two finds on every line and the running sum")
+	flag.Parse()
 
-func (s *Solution) PartOne(input string) interface{} {
-	return s.Solve(input, `\d`)
-}
+	// -stream only prints the two parts as text: the other formats run the
+	// registered solver and -explain needs every line at once.
+	if *stream && (*explain || *format != output.Text) {
+		fmt.Fprintln(os.Stderr, "trebuchet: -stream only prints text, drop -format or -explain")
+		os.Exit(2)
+	}
 
-func (s *Solution) PartTwo(input string) interface{} {
-	return s.Solve(input, `\d|one|two|three|four|five|six|seven|eight|nine`)
-}
+	v, err := trebuchet.LookupVocabulary(*words)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	v.IgnoreCase = v.IgnoreCase || *ignoreCase
+
+	// -ignore-case can make two words of a vocabulary the same.
+	if err := v.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-func readInputText(name string) string {
-	data, err := os.ReadFile(name)
+	if *explain {
+		if err := explainLines(*path, *format, v); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 
-	if err != nil {
-		panic(err)
+		return
 	}
 
-	return string(data)
-}
+	if *format != output.Text {
+		d, _ := aoc.Lookup(1)
+		d.New = func() aoc.Solver { return trebuchet.NewWith(v) }
+		if err := output.Run(os.Stdout, *format, d, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 
-func (s *Solution) Solve(input string, rx string) int {
-	lines := strings.Split(strings.Trim(input, " "), "\n")
-	sum := 0
+		return
+	}
 
-	for _, line := range lines {
-		first := regexp.MustCompile(rx).FindString(line)
-		last := regexp.MustCompile(rx).FindString(line)
+	err = input.Each(*path, os.Stdout, func(r io.Reader) error {
+		if *stream {
+			part1, part2, err := trebuchet.Stream(context.Background(), r, v, *workers)
+			if err != nil {
+				return err
+			}
 
-		match := regexp.MustCompile(rx).FindAllString(line, -1)
+			fmt.Println("Part One:", part1)
+			fmt.Println("Part Two:", part2)
 
-		if len(match) > 0 {
-			last = match[len(match)-1]
+			return nil
 		}
 
-		sum += s.ParseMatch(first)*10 + s.ParseMatch(last)
-	}
+		lines, err := trebuchet.Parse(r)
+		if err != nil {
+			return err
+		}
 
-	return sum
-}
+		part1, err := trebuchet.Part1(context.Background(), lines)
+		if err != nil {
+			return err
+		}
+
+		part2, err := trebuchet.Part2With(context.Background(), lines, v)
+		if err != nil {
+			return err
+		}
 
-func (s *Solution) ParseMatch(st string) int {
-	switch st {
-	case "one":
-		return 1
-	case "two":
-		return 2
-	case "three":
-		return 3
-	case "four":
-		return 4
-	case "five":
-		return 5
-	case "six":
-		return 6
-	case "seven":
-		return 7
-	case "eight":
-		return 8
-	case "nine":
-		return 9
-	default:
-		d, _ := strconv.Atoi(st)
-		return d
+		fmt.Println("Part One:", part1)
+		fmt.Println("Part Two:", part2)
+
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }
 
-func main() {
-	input := readInputText("input.txt")
-	solver := &Solution{}
-	fmt.Println("Part One:", solver.PartOne(input))
-	fmt.Println("Part Two:", solver.PartTwo(input))
+// explainLines writes how part two finds the calibration value of every line
+// of the inputs at path, warning on stderr about lines without any digit.
+func explainLines(path, format string, v trebuchet.Vocabulary) error {
+	if format != output.Text && format != "csv" {
+		return fmt.Errorf("trebuchet: unknown -explain format %q, want text or csv", format)
+	}
+
+	s := trebuchet.NewScanner(v)
+
+	return input.Each(path, os.Stdout, func(r io.Reader) error {
+		lines, err := trebuchet.Parse(r)
+		if err != nil {
+			return err
+		}
+
+		e := s.Explain(lines)
+		for _, c := range e.Warnings() {
+			fmt.Fprintf(os.Stderr, "warning: line %d has no digits: %q\n", c.Line, c.Text)
+		}
+
+		if format == "csv" {
+			return e.WriteCSV(os.Stdout)
+		}
+
+		return e.WriteText(os.Stdout)
+	})
 }
@@ -0,0 +1,105 @@
+package trebuchet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Calibration is how the calibration value of one line was found.
+type Calibration struct {
+	// Line is the 1-based line number and Text the line itself.
+	Line int
+	Text string
+	// First and Last are the digits the value is made of. They are only
+	// set when OK is; a line without any digit is worth 0.
+	First, Last Match
+	OK          bool
+	Value       int
+	// Sum is the running sum of the values up to and including this line.
+	Sum int
+}
+
+// Explanation lists the calibration of every line of a document.
+type Explanation []Calibration
+
+// Explain returns the calibration of every line, as Sum adds them up.
+func (s *Scanner) Explain(lines []string) Explanation {
+	e := make(Explanation, len(lines))
+	sum := 0
+
+	for i, line := range lines {
+		c := Calibration{Line: i + 1, Text: line}
+		c.First, c.Last, c.OK = s.Scan(line)
+		if c.OK {
+			c.Value = c.First.Value*10 + c.Last.Value
+		}
+
+		sum += c.Value
+		c.Sum = sum
+		e[i] = c
+	}
+
+	return e
+}
+
+// Warnings returns the lines that hold no digit and so add nothing.
+func (e Explanation) Warnings() []Calibration {
+	var warnings []Calibration
+	for _, c := range e {
+		if !c.OK {
+			warnings = append(warnings, c)
+		}
+	}
+
+	return warnings
+}
+
+// WriteText writes the explanation as an aligned table. Offsets are bytes
+// into the line, from 0.
+func (e Explanation) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tFIRST\tAT\tLAST\tAT\tVALUE\tSUM\tTEXT\t")
+
+	for _, c := range e {
+		if !c.OK {
+			fmt.Fprintf(tw, "%d\t-\t-\t-\t-\t%d\t%d\t%s\tno digits\n", c.Line, c.Value, c.Sum, c.Text)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%d\t%d\t%d\t%s\t\n",
+			c.Line, c.First.Text, c.First.Offset, c.Last.Text, c.Last.Offset, c.Value, c.Sum, c.Text)
+	}
+
+	return tw.Flush()
+}
+
+// WriteCSV writes one row per line. The match columns of a line without any
+// digit are empty and its warning column says so.
+func (e Explanation) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"line", "first", "first_value", "first_offset", "last", "last_value", "last_offset", "value", "sum", "text", "warning"})
+
+	for _, c := range e {
+		row := []string{strconv.Itoa(c.Line), "", "", "", "", "", "", strconv.Itoa(c.Value), strconv.Itoa(c.Sum), c.Text, "no digits"}
+		if c.OK {
+			copy(row[1:7], []string{
+				c.First.Text,
+				strconv.Itoa(c.First.Value),
+				strconv.Itoa(c.First.Offset),
+				c.Last.Text,
+				strconv.Itoa(c.Last.Value),
+				strconv.Itoa(c.Last.Offset),
+			})
+			row[10] = ""
+		}
+
+		cw.Write(row)
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,97 @@
+package trebuchet_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+)
+
+var explained = []string{"two1nine", "abc", "eightwo3", `a,"b"`}
+
+func TestExplain(t *testing.T) {
+	s := trebuchet.NewScanner(trebuchet.English)
+	e := s.Explain(explained)
+
+	tests := []struct {
+		first, last trebuchet.Match
+		ok          bool
+		value, sum  int
+	}{
+		{trebuchet.Match{Value: 2, Text: "two", Offset: 0}, trebuchet.Match{Value: 9, Text: "nine", Offset: 4}, true, 29, 29},
+		{trebuchet.Match{}, trebuchet.Match{}, false, 0, 29},
+		{trebuchet.Match{Value: 8, Text: "eight", Offset: 0}, trebuchet.Match{Value: 3, Text: "3", Offset: 7}, true, 83, 112},
+		{trebuchet.Match{}, trebuchet.Match{}, false, 0, 112},
+	}
+
+	if len(e) != len(tests) {
+		t.Fatalf("Explain returned %d lines, want %d", len(e), len(tests))
+	}
+
+	for i, tt := range tests {
+		c := e[i]
+		if c.Line != i+1 || c.Text != explained[i] || c.First != tt.first || c.Last != tt.last || c.OK != tt.ok || c.Value != tt.value || c.Sum != tt.sum {
+			t.Errorf("line %d = %+v, want %+v", i+1, c, tt)
+		}
+	}
+
+	if e[len(e)-1].Sum != s.Sum(explained) {
+		t.Errorf("the last running sum %d is not Sum %d", e[len(e)-1].Sum, s.Sum(explained))
+	}
+
+	var warned []int
+	for _, c := range e.Warnings() {
+		warned = append(warned, c.Line)
+	}
+
+	if !slices.Equal(warned, []int{2, 4}) {
+		t.Errorf("Warnings = lines %v, want 2 and 4", warned)
+	}
+}
+
+func TestExplanationWrite(t *testing.T) {
+	e := trebuchet.NewScanner(trebuchet.English).Explain(explained)
+
+	tests := []struct {
+		name  string
+		write func(*strings.Builder) error
+		want  []string
+	}{
+		{
+			"text",
+			func(b *strings.Builder) error { return e.WriteText(b) },
+			[]string{
+				"LINE  FIRST  AT  LAST  AT  VALUE  SUM  TEXT      ",
+				"1     two    0   nine  4   29     29   two1nine  ",
+				"2     -      -   -     -   0      29   abc       no digits",
+				"3     eight  0   3     7   83     112  eightwo3  ",
+				`4     -      -   -     -   0      112  a,"b"     no digits`,
+			},
+		},
+		{
+			"CSV",
+			func(b *strings.Builder) error { return e.WriteCSV(b) },
+			[]string{
+				"line,first,first_value,first_offset,last,last_value,last_offset,value,sum,text,warning",
+				"1,two,2,0,nine,9,4,29,29,two1nine,",
+				"2,,,,,,,0,29,abc,no digits",
+				"3,eight,8,0,3,3,7,83,112,eightwo3,",
+				`4,,,,,,,0,112,"a,""b""",no digits`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := tt.write(&b); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := b.String(), strings.Join(tt.want, "\n")+"\n"; got != want {
+				t.Errorf("output =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
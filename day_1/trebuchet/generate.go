@@ -0,0 +1,38 @@
+package trebuchet
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+var words = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// Generate writes a random calibration document of scale times 1000 lines.
+// Every line mixes letters, number words and at least one digit.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	bw := bufio.NewWriter(w)
+	for i := gen.Count(1000, scale); i > 0; i-- {
+		var line []byte
+		for n := gen.Between(rng, 5, 40); len(line) < n; {
+			switch r := rng.Intn(10); {
+			case r < 1:
+				line = append(line, byte(gen.Between(rng, '1', '9')))
+			case r < 3:
+				line = append(line, words[rng.Intn(len(words))]...)
+			default:
+				line = append(line, byte(gen.Between(rng, 'a', 'z')))
+			}
+		}
+
+		at := rng.Intn(len(line) + 1)
+		line = append(line[:at], append([]byte{byte(gen.Between(rng, '1', '9'))}, line[at:]...)...)
+
+		bw.Write(line)
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,212 @@
+package trebuchet
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+var (
+	digitScanner = NewScanner(Vocabulary{})
+	wordScanner  = NewScanner(English)
+)
+
+// checkEvery is how many lines are scanned between two looks at whether the
+// context is done.
+const checkEvery = 1024
+
+// Match is a digit found on a line: its value, how it is spelled and the
+// byte offset it starts at.
+type Match struct {
+	Value  int
+	Text   string
+	Offset int
+}
+
+// Scanner finds the first and last digit of a line, written as a decimal
+// digit or spelled out as one of the words of its vocabulary. Words may
+// overlap: in "twone" the first digit is 2 and the last is 1.
+type Scanner struct {
+	// ascii and other list the words by their first rune, folded to lower
+	// case when ignoring case, longest first.
+	ascii      [utf8.RuneSelf][]word
+	other      map[rune][]word
+	ignoreCase bool
+}
+
+type word struct {
+	text  string
+	value int
+	// plain words match byte for byte.
+	plain bool
+}
+
+// NewScanner returns a scanner for decimal digits and the words of v. An
+// empty vocabulary only finds digits.
+func NewScanner(v Vocabulary) *Scanner {
+	s := &Scanner{other: make(map[rune][]word), ignoreCase: v.IgnoreCase}
+	for text, value := range v.Words {
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" {
+			continue
+		}
+
+		w := word{text: text, value: value, plain: !v.IgnoreCase && !strings.Contains(text, " ")}
+		r := s.fold(firstRune(text))
+		if r < utf8.RuneSelf {
+			s.ascii[r] = append(s.ascii[r], w)
+		} else {
+			s.other[r] = append(s.other[r], w)
+		}
+	}
+
+	longestFirst := func(words []word) {
+		sort.Slice(words, func(a, b int) bool {
+			wa, wb := words[a], words[b]
+			return len(wa.text) > len(wb.text) || len(wa.text) == len(wb.text) && wa.text < wb.text
+		})
+	}
+
+	for _, words := range s.ascii {
+		longestFirst(words)
+	}
+
+	for _, words := range s.other {
+		longestFirst(words)
+	}
+
+	return s
+}
+
+// Scan returns the first and last digit of line, looking for the first from
+// the start and for the last from the end, so that no byte is read twice.
+// ok is false when the line holds no digit.
+func (s *Scanner) Scan(line string) (first, last Match, ok bool) {
+	for i := 0; i < len(line) && !ok; i++ {
+		first, ok = s.matchAt(line, i)
+	}
+
+	if !ok {
+		return Match{}, Match{}, false
+	}
+
+	for i := len(line) - 1; i >= first.Offset; i-- {
+		if last, ok = s.matchAt(line, i); ok {
+			break
+		}
+	}
+
+	return first, last, true
+}
+
+// Value returns the calibration value of line, made of its first and last
+// digit. A line without any digit is worth 0.
+func (s *Scanner) Value(line string) int {
+	first, last, ok := s.Scan(line)
+	if !ok {
+		return 0
+	}
+
+	return first.Value*10 + last.Value
+}
+
+// Sum returns the sum of the calibration values of lines.
+func (s *Scanner) Sum(lines []string) int {
+	sum := 0
+	for _, line := range lines {
+		sum += s.Value(line)
+	}
+
+	return sum
+}
+
+// sum is Sum that stops with ctx.Err() once ctx is done.
+func (s *Scanner) sum(ctx context.Context, lines []string) (int, error) {
+	sum := 0
+	for i, line := range lines {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+
+			aoc.ReportProgress(ctx, "lines", i, len(lines))
+		}
+
+		sum += s.Value(line)
+	}
+
+	return sum, nil
+}
+
+// matchAt returns the digit starting at byte i of line, if any.
+func (s *Scanner) matchAt(line string, i int) (Match, bool) {
+	c := line[i]
+	if '0' <= c && c <= '9' {
+		return Match{Value: int(c - '0'), Text: line[i : i+1], Offset: i}, true
+	}
+
+	var words []word
+	if c < utf8.RuneSelf {
+		words = s.ascii[s.fold(rune(c))]
+	} else {
+		words = s.other[s.fold(firstRune(line[i:]))]
+	}
+
+	for _, w := range words {
+		if n, ok := s.prefix(line[i:], w); ok {
+			return Match{Value: w.value, Text: line[i : i+n], Offset: i}, true
+		}
+	}
+
+	return Match{}, false
+}
+
+// prefix returns how many bytes at the start of text spell w.
+func (s *Scanner) prefix(text string, w word) (int, bool) {
+	if w.plain {
+		return len(w.text), strings.HasPrefix(text, w.text)
+	}
+
+	n := 0
+	for _, wr := range w.text {
+		if wr == ' ' {
+			start := n
+			for n < len(text) && (text[n] == ' ' || text[n] == '\t') {
+				n++
+			}
+
+			if n == start {
+				return 0, false
+			}
+
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if size == 0 || r != wr && s.fold(r) != s.fold(wr) {
+			return 0, false
+		}
+
+		n += size
+	}
+
+	return n, true
+}
+
+// fold returns r in lower case when ignoring case.
+func (s *Scanner) fold(r rune) rune {
+	if s.ignoreCase {
+		return unicode.ToLower(r)
+	}
+
+	return r
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
@@ -0,0 +1,65 @@
+package trebuchet
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{
+		Day: 1, Title: "Trebuchet?!", Dir: "day_1", New: New, Version: "2", Examples: examples, Generate: Generate,
+		Alternates: []aoc.Alternate{
+			// the regular expressions misread overlapping words at the end
+			// of a line, so they only stand in for part one.
+			{Name: "regex", Parts: []int{1}, New: func() aoc.Solver { return &regex{} }},
+		},
+	})
+}
+
+// New returns an aoc.Solver for day 1.
+func New() aoc.Solver {
+	return &solver{words: wordScanner}
+}
+
+// NewWith returns an aoc.Solver for day 1 that reads the number words of v
+// in part two.
+func NewWith(v Vocabulary) aoc.Solver {
+	return &solver{words: NewScanner(v)}
+}
+
+type solver struct {
+	lines []string
+	words *Scanner
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.lines, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.lines)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return s.words.sum(ctx, s.lines)
+}
+
+// regex finds the digits with regular expressions instead of a Scanner.
+type regex struct {
+	solver
+}
+
+func (s *regex) PartOne(ctx context.Context) (any, error) {
+	return (&Solution{}).SolveLines(ctx, s.lines, Digits)
+}
+
+func (s *regex) PartTwo(ctx context.Context) (any, error) {
+	return (&Solution{}).SolveLines(ctx, s.lines, DigitsAndWords)
+}
@@ -0,0 +1,67 @@
+package trebuchet
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+// ChunkSize is how much of a streamed document a worker scans at a time.
+const ChunkSize = 1 << 20
+
+// SumReader is Sum for every scanner over the lines of a document read from
+// r, without holding more of it in memory than a few chunks per worker. The
+// document is scanned by workers goroutines at once, or by one per CPU when
+// workers is 0, and it is read only once however many scanners there are.
+func SumReader(ctx context.Context, r io.Reader, workers int, scanners ...*Scanner) ([]int, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// partial holds the sums of every worker, merged once they are done.
+	partial := make([][]int, workers)
+	for i := range partial {
+		partial[i] = make([]int, len(scanners))
+	}
+
+	err := input.Stream(ctx, r, ChunkSize, workers, func(worker int, chunk []byte) error {
+		text := string(chunk)
+		sums := partial[worker]
+
+		for text != "" {
+			var line string
+			line, text, _ = strings.Cut(text, "\n")
+			for i, s := range scanners {
+				sums[i] += s.Value(line)
+			}
+		}
+
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make([]int, len(scanners))
+	for _, p := range partial {
+		for i, sum := range p {
+			sums[i] += sum
+		}
+	}
+
+	return sums, nil
+}
+
+// Stream is Part1 and Part2With of a document read from r, in one pass and
+// in constant memory. See SumReader for workers.
+func Stream(ctx context.Context, r io.Reader, v Vocabulary, workers int) (part1, part2 int, err error) {
+	sums, err := SumReader(ctx, r, workers, digitScanner, NewScanner(v))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return sums[0], sums[1], nil
+}
@@ -0,0 +1,88 @@
+package trebuchet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+// document returns a generated calibration document several chunks long,
+// so that streaming it splits lines across chunks.
+func document(t *testing.T) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := trebuchet.Generate(&buf, rand.New(rand.NewSource(1)), 200); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.Len() < 3*trebuchet.ChunkSize {
+		t.Fatalf("the document is %d bytes, want at least three chunks", buf.Len())
+	}
+
+	return buf.String()
+}
+
+func TestStreamMatchesParts(t *testing.T) {
+	doc := document(t)
+
+	lines, err := trebuchet.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part1, err := trebuchet.Part1(context.Background(), lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part2, err := trebuchet.Part2(context.Background(), lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		workers int
+	}{
+		{"one worker", doc, 1},
+		{"several workers", doc, 4},
+		{"one per CPU", doc, 0},
+		{"byte order mark", "\xEF\xBB\xBF" + doc, 3},
+		{"CRLF line endings", strings.ReplaceAll(doc, "\n", "\r\n"), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got1, got2, err := trebuchet.Stream(context.Background(), strings.NewReader(tt.text), trebuchet.English, tt.workers)
+			if err != nil || got1 != part1 || got2 != part2 {
+				t.Errorf("Stream = %d, %d, %v, want %d, %d", got1, got2, err, part1, part2)
+			}
+		})
+	}
+}
+
+func TestStreamReportsLinePastFirstChunk(t *testing.T) {
+	doc := document(t)
+
+	// break a line in the last chunk.
+	at := len(doc) - 10
+	line := strings.Count(doc[:at], "\n") + 1
+	column := at - strings.LastIndexByte(doc[:at], '\n')
+
+	bad := doc[:at] + "\xFF" + doc[at+1:]
+
+	_, _, err := trebuchet.Stream(context.Background(), strings.NewReader(bad), trebuchet.English, 4)
+
+	var encErr *input.EncodingError
+	if !errors.As(err, &encErr) || encErr.Line != line || encErr.Column != column {
+		t.Errorf("Stream error = %v, want an *input.EncodingError at line %d, column %d", err, line, column)
+	}
+}
@@ -0,0 +1,106 @@
+// Package trebuchet solves day 1 of Advent of Code 2023, "Trebuchet?!".
+package trebuchet
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+const (
+	// Digits matches a single decimal digit.
+	Digits = `\d`
+	// DigitsAndWords matches a decimal digit or an English number word.
+	DigitsAndWords = `\d|one|two|three|four|five|six|seven|eight|nine`
+)
+
+// Parse reads a calibration document from r and returns its lines.
+func Parse(r io.Reader) ([]string, error) {
+	return input.Lines(r)
+}
+
+// Part1 sums the calibration values made of the first and last digit of every line.
+// It stops with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, lines []string) (int, error) {
+	return digitScanner.sum(ctx, lines)
+}
+
+// Part2 sums the calibration values when digits may also be spelled out as words.
+// It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, lines []string) (int, error) {
+	return wordScanner.sum(ctx, lines)
+}
+
+// Part2With is Part2 with the number words of v instead of English ones.
+func Part2With(ctx context.Context, lines []string, v Vocabulary) (int, error) {
+	return NewScanner(v).sum(ctx, lines)
+}
+
+// Solution computes calibration values with a configurable digit pattern.
+// It is the original regular expression solution, kept as it was to measure
+// the Scanner used by Part1 and Part2 against. It takes the last of the
+// matches that do not overlap, so it reads "twone" as 22 rather than 21.
+type Solution struct{}
+
+// PartOne solves part one for the raw input.
+func (s *Solution) PartOne(input string) interface{} {
+	return s.Solve(input, Digits)
+}
+
+// PartTwo solves part two for the raw input.
+func (s *Solution) PartTwo(input string) interface{} {
+	return s.Solve(input, DigitsAndWords)
+}
+
+// Solve sums the calibration values of text, finding digits with the rx pattern.
+func (s *Solution) Solve(text string, rx string) int {
+	// an empty document has no calibration values and sums to zero.
+	lines, _ := input.Lines(strings.NewReader(text))
+	sum, _ := s.SolveLines(context.Background(), lines, rx)
+
+	return sum
+}
+
+// SolveLines is Solve for an input already split into lines. It stops with
+// ctx.Err() once ctx is done.
+func (s *Solution) SolveLines(ctx context.Context, lines []string, rx string) (int, error) {
+	sum := 0
+
+	for i, line := range lines {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		first := regexp.MustCompile(rx).FindString(line)
+		last := regexp.MustCompile(rx).FindString(line)
+
+		match := regexp.MustCompile(rx).FindAllString(line, -1)
+
+		if len(match) > 0 {
+			last = match[len(match)-1]
+		}
+
+		sum += s.ParseMatch(first)*10 + s.ParseMatch(last)
+	}
+
+	return sum, nil
+}
+
+// ParseMatch returns the value of a single match, either a digit or a number
+// word. A line without any match contributes an empty match worth 0.
+func (s *Solution) ParseMatch(st string) int {
+	if value, ok := English.Words[st]; ok {
+		return value
+	}
+
+	if len(st) == 1 && '0' <= st[0] && st[0] <= '9' {
+		return int(st[0] - '0')
+	}
+
+	return 0
+}
@@ -0,0 +1,122 @@
+package trebuchet_test
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+)
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		line         string
+		part1, part2 int
+	}{
+		{"1abc2", 12, 12},
+		{"treb7uchet", 77, 77},
+		{"two1nine", 11, 29},
+		{"4nineeightseven2", 42, 42},
+		{"7pqrstsixteen", 77, 76},
+		{"twone", 0, 21},
+		{"oneight", 0, 18},
+		{"eightwothree", 0, 83},
+		{"xtwone3four", 33, 24},
+		{"3twone", 33, 31},
+		{"sevenine", 0, 79},
+		{"nothing here", 0, 0},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		lines := []string{tt.line}
+
+		if got, err := trebuchet.Part1(context.Background(), lines); err != nil || got != tt.part1 {
+			t.Errorf("Part1(%q) = %d, %v, want %d", tt.line, got, err, tt.part1)
+		}
+
+		if got, err := trebuchet.Part2(context.Background(), lines); err != nil || got != tt.part2 {
+			t.Errorf("Part2(%q) = %d, %v, want %d", tt.line, got, err, tt.part2)
+		}
+	}
+}
+
+func TestScannerMatchesRegex(t *testing.T) {
+	var b strings.Builder
+	if err := trebuchet.Generate(&b, rand.New(rand.NewSource(1)), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	solution := &trebuchet.Solution{}
+
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		lines := []string{line}
+
+		want, _ := solution.SolveLines(ctx, lines, trebuchet.Digits)
+		if got, err := trebuchet.Part1(ctx, lines); err != nil || got != want {
+			t.Errorf("Part1(%q) = %d, %v, regex gives %d", line, got, err, want)
+		}
+
+		// the regular expressions take the last match that does not overlap
+		// the one before it, so only words that overlap may tell them apart.
+		want, _ = solution.SolveLines(ctx, lines, trebuchet.DigitsAndWords)
+		if got, err := trebuchet.Part2(ctx, lines); err != nil || got != want && !overlapping(line) {
+			t.Errorf("Part2(%q) = %d, %v, regex gives %d", line, got, err, want)
+		}
+	}
+
+	if got, _ := solution.SolveLines(ctx, []string{"twone"}, trebuchet.DigitsAndWords); got != 22 {
+		t.Errorf("regex reads twone as %d, want 22", got)
+	}
+}
+
+// overlapping reports whether line holds two number words that share
+// letters, such as "twone".
+func overlapping(line string) bool {
+	words := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+	for _, v := range words {
+		for _, w := range words {
+			for k := 1; k < min(len(v), len(w)); k++ {
+				if v[len(v)-k:] == w[:k] && strings.Contains(line, v+w[k:]) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func BenchmarkParts(b *testing.B) {
+	var doc strings.Builder
+	if err := trebuchet.Generate(&doc, rand.New(rand.NewSource(1)), 10); err != nil {
+		b.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(doc.String()), "\n")
+	ctx := context.Background()
+	solution := &trebuchet.Solution{}
+
+	benchmarks := []struct {
+		name  string
+		solve func() (int, error)
+	}{
+		{"part1/scanner", func() (int, error) { return trebuchet.Part1(ctx, lines) }},
+		{"part1/regex", func() (int, error) { return solution.SolveLines(ctx, lines, trebuchet.Digits) }},
+		{"part2/scanner", func() (int, error) { return trebuchet.Part2(ctx, lines) }},
+		{"part2/regex", func() (int, error) { return solution.SolveLines(ctx, lines, trebuchet.DigitsAndWords) }},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.SetBytes(int64(doc.Len()))
+			for i := 0; i < b.N; i++ {
+				if _, err := bm.solve(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package trebuchet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Vocabulary maps the number words of a language, or of any custom encoding,
+// to the digits they spell. A word may be made of several words separated by
+// spaces, which match any run of spaces and tabs in a line.
+type Vocabulary struct {
+	Name  string         `json:"name,omitempty"`
+	Words map[string]int `json:"words"`
+	// IgnoreCase makes words match regardless of case.
+	IgnoreCase bool `json:"ignore_case,omitempty"`
+}
+
+// The built-in vocabularies: the number words from one to nine.
+var (
+	English = Vocabulary{Name: "en", Words: map[string]int{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}}
+	German = Vocabulary{Name: "de", Words: map[string]int{
+		"eins": 1, "zwei": 2, "drei": 3, "vier": 4, "fünf": 5,
+		"sechs": 6, "sieben": 7, "acht": 8, "neun": 9,
+	}}
+	Spanish = Vocabulary{Name: "es", Words: map[string]int{
+		"uno": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5,
+		"seis": 6, "siete": 7, "ocho": 8, "nueve": 9,
+	}}
+	Polish = Vocabulary{Name: "pl", Words: map[string]int{
+		"jeden": 1, "dwa": 2, "trzy": 3, "cztery": 4, "pięć": 5,
+		"sześć": 6, "siedem": 7, "osiem": 8, "dziewięć": 9,
+	}}
+)
+
+// Vocabularies lists the built-in vocabularies by name.
+var Vocabularies = map[string]Vocabulary{
+	English.Name: English,
+	German.Name:  German,
+	Spanish.Name: Spanish,
+	Polish.Name:  Polish,
+}
+
+// LookupVocabulary returns the built-in vocabulary called name, or else
+// loads the vocabulary file at name.
+func LookupVocabulary(name string) (Vocabulary, error) {
+	if v, ok := Vocabularies[name]; ok {
+		return v, nil
+	}
+
+	if _, err := os.Stat(name); err != nil {
+		return Vocabulary{}, fmt.Errorf("trebuchet: %q is neither a built-in vocabulary (%s) nor a readable file: %w", name, builtinNames(), err)
+	}
+
+	return LoadVocabulary(name)
+}
+
+func builtinNames() string {
+	names := make([]string, 0, len(Vocabularies))
+	for name := range Vocabularies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// LoadVocabulary reads a vocabulary from the JSON file at path, such as
+//
+//	{"name": "fr", "ignore_case": true, "words": {"un": 1, "deux": 2, "trois": 3}}
+//
+// The name defaults to the file name. A word listed twice is an error
+// rather than the last one winning.
+func LoadVocabulary(path string) (Vocabulary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Vocabulary{}, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var file struct {
+		Name       string          `json:"name"`
+		Words      json.RawMessage `json:"words"`
+		IgnoreCase bool            `json:"ignore_case"`
+	}
+	if err := dec.Decode(&file); err != nil {
+		return Vocabulary{}, fmt.Errorf("trebuchet: %s: %w", path, err)
+	}
+
+	v := Vocabulary{Name: file.Name, IgnoreCase: file.IgnoreCase}
+	if v.Words, err = decodeWords(file.Words); err != nil {
+		return Vocabulary{}, fmt.Errorf("trebuchet: %s: %w", path, err)
+	}
+
+	if v.Name == "" {
+		v.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if err := v.Validate(); err != nil {
+		return Vocabulary{}, fmt.Errorf("%w in %s", err, path)
+	}
+
+	return v, nil
+}
+
+// decodeWords decodes the words object of a vocabulary file, failing on a
+// word that appears twice.
+func decodeWords(data json.RawMessage) (map[string]int, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("words: want an object of words and digits")
+	}
+
+	words := make(map[string]int)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		word := tok.(string)
+		if _, ok := words[word]; ok {
+			return nil, fmt.Errorf("word %q is listed twice", word)
+		}
+
+		var value int
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("word %q: %w", word, err)
+		}
+
+		words[word] = value
+	}
+
+	return words, nil
+}
+
+// Validate returns an error unless every word is non-empty valid UTF-8 that
+// does not start or end with a space, and stands for a digit from 0 to 9.
+// Two words are also an error when they only differ in their spaces, or in
+// case when ignoring it, since the scanner could not tell them apart.
+func (v Vocabulary) Validate() error {
+	if len(v.Words) == 0 {
+		return fmt.Errorf("trebuchet: vocabulary %q has no words", v.Name)
+	}
+
+	words := make([]string, 0, len(v.Words))
+	for word := range v.Words {
+		words = append(words, word)
+	}
+
+	sort.Strings(words)
+
+	seen := make(map[string]string, len(words))
+	for _, word := range words {
+		value := v.Words[word]
+
+		switch {
+		case strings.TrimSpace(word) == "" || strings.TrimSpace(word) != word || !utf8.ValidString(word):
+			return fmt.Errorf("trebuchet: vocabulary %q: invalid word %q", v.Name, word)
+		case value < 0 || value > 9:
+			return fmt.Errorf("trebuchet: vocabulary %q: word %q stands for %d, want a digit from 0 to 9", v.Name, word, value)
+		}
+
+		key := strings.Join(strings.Fields(word), " ")
+		if v.IgnoreCase {
+			key = strings.Map(unicode.ToLower, key)
+		}
+
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("trebuchet: vocabulary %q: words %q and %q are the same word", v.Name, other, word)
+		}
+
+		seen[key] = word
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package trebuchet_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_1/trebuchet"
+)
+
+func TestScannerVocabulary(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     trebuchet.Vocabulary
+		line  string
+		value int
+	}{
+		{"case matters", trebuchet.English, "One2threE", 22},
+		{"ignoring case", trebuchet.Vocabulary{Words: trebuchet.English.Words, IgnoreCase: true}, "One2threE", 13},
+		{"ignoring case beyond ASCII", trebuchet.Vocabulary{Words: trebuchet.Polish.Words, IgnoreCase: true}, "xPIĘĆy DWA", 52},
+		{"non-ASCII words", trebuchet.German, "fünf und zwei", 52},
+		{"multi-word entries", trebuchet.Vocabulary{Words: map[string]int{"twenty one": 1, "two": 2}}, "twenty \t one, two", 12},
+		{"multi-word entries need their space", trebuchet.Vocabulary{Words: map[string]int{"twenty one": 1}}, "twentyone 5", 55},
+		{"longest entry first", trebuchet.Vocabulary{Words: map[string]int{"ab": 2, "a": 1}}, "xab", 22},
+		{"shorter entry where the longer one does not fit", trebuchet.Vocabulary{Words: map[string]int{"ab": 2, "a": 1}}, "ab-a", 21},
+		{"overlapping words", trebuchet.English, "eightwone", 81},
+		{"no words", trebuchet.Vocabulary{}, "one2three", 22},
+		{"no digits", trebuchet.Spanish, "one two", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trebuchet.NewScanner(tt.v).Value(tt.line); got != tt.value {
+				t.Errorf("Value(%q) = %d, want %d", tt.line, got, tt.value)
+			}
+		})
+	}
+}
+
+func TestLookupVocabulary(t *testing.T) {
+	for name := range trebuchet.Vocabularies {
+		if v, err := trebuchet.LookupVocabulary(name); err != nil || v.Name != name {
+			t.Errorf("LookupVocabulary(%s) = %q, %v", name, v.Name, err)
+		}
+	}
+
+	if _, err := trebuchet.LookupVocabulary("xx"); err == nil || !strings.Contains(err.Error(), "de, en, es, pl") {
+		t.Errorf("LookupVocabulary(xx) error = %v, want one listing the built-in vocabularies", err)
+	}
+}
+
+func TestLoadVocabulary(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		ok   bool
+	}{
+		{"words", `{"name": "fr", "words": {"un": 1, "deux": 2}}`, true},
+		{"multi-word", `{"words": {"twenty one": 1}}`, true},
+		{"same word in other cases", `{"words": {"un": 1, "UN": 1}}`, true},
+		{"no words", `{"name": "fr", "words": {}}`, false},
+		{"words missing", `{"name": "fr"}`, false},
+		{"empty word", `{"words": {"": 1, "un": 1}}`, false},
+		{"blank word", `{"words": {"  ": 1}}`, false},
+		{"padded word", `{"words": {" un": 1}}`, false},
+		{"duplicate word", `{"words": {"un": 1, "deux": 2, "un": 3}}`, false},
+		{"duplicate but for spaces", `{"words": {"vingt et un": 1, "vingt  et un": 1}}`, false},
+		{"duplicate ignoring case", `{"ignore_case": true, "words": {"un": 1, "UN": 1}}`, false},
+		{"not a digit", `{"words": {"dix": 10}}`, false},
+		{"negative", `{"words": {"moins": -1}}`, false},
+		{"not a number", `{"words": {"un": "1"}}`, false},
+		{"unknown field", `{"words": {"un": 1}, "language": "fr"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "custom.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			v, err := trebuchet.LoadVocabulary(path)
+			if (err == nil) != tt.ok {
+				t.Fatalf("LoadVocabulary(%s) = %v, %v, want ok %t", tt.json, v, err, tt.ok)
+			}
+
+			if tt.ok && v.Name == "" {
+				t.Errorf("LoadVocabulary(%s) has no name", tt.json)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "roman.json")
+	if err := os.WriteFile(path, []byte(`{"words": {"I": 1}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := trebuchet.LookupVocabulary(path); err != nil || v.Name != "roman" || v.Words["I"] != 1 {
+		t.Errorf("LookupVocabulary(%s) = %v, %v, want the roman vocabulary named after its file", path, v, err)
+	}
+}
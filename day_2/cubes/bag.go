@@ -0,0 +1,123 @@
+package cubes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Bag is what the games are played with: how many cubes of each color the
+// bag holds.
+type Bag struct {
+	Cubes map[string]int `json:"cubes"`
+	// AllowUnknown lets a record of games show colors the bag does not hold
+	// instead of failing to parse. A game that shows any is impossible.
+	AllowUnknown bool `json:"allow_unknown,omitempty"`
+}
+
+// Standard is the bag of the puzzle.
+var Standard = Bag{Cubes: map[string]int{"red": 12, "green": 13, "blue": 14}}
+
+// ParseBag reads the cubes of a bag written like a handful of the puzzle,
+// "12 red, 13 green, 14 blue".
+func ParseBag(s string) (Bag, error) {
+	c := parse.NewCursor(1, s)
+
+	cubes, err := parseSubset(c, Bag{AllowUnknown: true})
+	if err == nil {
+		err = c.End()
+	}
+
+	if err != nil {
+		return Bag{}, fmt.Errorf("cubes: bag %q: %w", s, err)
+	}
+
+	bag := Bag{Cubes: cubes}
+	if err := bag.Validate(); err != nil {
+		return Bag{}, err
+	}
+
+	return bag, nil
+}
+
+// LoadBag reads a bag from the JSON file at path, such as
+//
+//	{"cubes": {"red": 12, "green": 13, "blue": 14, "yellow": 5}, "allow_unknown": true}
+func LoadBag(path string) (Bag, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Bag{}, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var bag Bag
+	if err := dec.Decode(&bag); err != nil {
+		return Bag{}, fmt.Errorf("cubes: %s: %w", path, err)
+	}
+
+	if err := bag.Validate(); err != nil {
+		return Bag{}, fmt.Errorf("%w in %s", err, path)
+	}
+
+	return bag, nil
+}
+
+// Validate returns an error unless the bag holds at least one color, every
+// color is a word of ASCII letters and no count is negative.
+func (b Bag) Validate() error {
+	if len(b.Cubes) == 0 {
+		return fmt.Errorf("cubes: the bag holds no colors")
+	}
+
+	for color, n := range b.Cubes {
+		switch {
+		case color == "" || strings.IndexFunc(color, func(r rune) bool { return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') }) >= 0:
+			return fmt.Errorf("cubes: invalid color %q, want a word of ASCII letters", color)
+		case n < 0:
+			return fmt.Errorf("cubes: the bag holds %d %s cubes", n, color)
+		}
+	}
+
+	return nil
+}
+
+// String returns the cubes of the bag as ParseBag reads them, by color.
+func (b Bag) String() string {
+	handful := make([]string, 0, len(b.Cubes))
+	for _, color := range b.colors() {
+		handful = append(handful, strconv.Itoa(b.Cubes[color])+" "+color)
+	}
+
+	return strings.Join(handful, ", ")
+}
+
+// colors returns the colors of the bag in alphabetical order.
+func (b Bag) colors() []string {
+	colors := make([]string, 0, len(b.Cubes))
+	for color := range b.Cubes {
+		colors = append(colors, color)
+	}
+
+	sort.Strings(colors)
+
+	return colors
+}
+
+// colorList returns the colors of the bag for an error message, such as
+// "blue, green or red".
+func (b Bag) colorList() string {
+	colors := b.colors()
+	if len(colors) < 2 {
+		return strings.Join(colors, "")
+	}
+
+	return strings.Join(colors[:len(colors)-1], ", ") + " or " + colors[len(colors)-1]
+}
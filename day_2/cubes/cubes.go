@@ -0,0 +1,185 @@
+// Package cubes solves day 2 of Advent of Code 2023, "Cube Conundrum".
+package cubes
+
+import (
+	"context"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Subset is one handful of cubes revealed during a game: how many cubes of
+// each color it holds.
+type Subset map[string]int
+
+// Game is a single line of the puzzle input.
+type Game struct {
+	ID      int
+	Subsets []Subset
+}
+
+// Parse reads the record of games from r, played with the Standard bag.
+func Parse(r io.Reader) ([]Game, error) {
+	return ParseWith(r, Standard)
+}
+
+// ParseWith reads the record of games from r. A color the bag does not
+// hold is an error unless the bag allows unknown colors.
+func ParseWith(r io.Reader, bag Bag) ([]Game, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make([]Game, len(lines))
+	for i, line := range lines {
+		game, err := parseGame(parse.NewCursor(i+1, line), bag)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed[i] = game
+	}
+
+	return parsed, nil
+}
+
+// parses a line of the form "Game 1: 3 blue, 4 red; 1 red, 2 green".
+func parseGame(c *parse.Cursor, bag Bag) (Game, error) {
+	if err := c.Expect("Game "); err != nil {
+		return Game{}, err
+	}
+
+	gameNumber, err := c.Int()
+	if err != nil {
+		return Game{}, err
+	}
+
+	if err := c.Expect(":"); err != nil {
+		return Game{}, err
+	}
+
+	subsets := make([]Subset, 0)
+	for {
+		subset, err := parseSubset(c, bag)
+		if err != nil {
+			return Game{}, err
+		}
+
+		subsets = append(subsets, subset)
+
+		if c.Done() {
+			break
+		}
+
+		if err := c.Expect(";"); err != nil {
+			return Game{}, err
+		}
+	}
+
+	return Game{ID: gameNumber, Subsets: subsets}, nil
+}
+
+// parses a handful of the form "3 blue, 4 red". Cubes of a color named
+// twice add up.
+func parseSubset(c *parse.Cursor, bag Bag) (Subset, error) {
+	subset := make(Subset)
+	for {
+		c.SkipSpaces()
+
+		number, err := c.Int()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.Expect(" "); err != nil {
+			return nil, err
+		}
+
+		column := c.Column()
+		color, err := c.Word()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := bag.Cubes[color]; !ok && !bag.AllowUnknown {
+			return nil, &parse.Error{Line: c.Line(), Column: column, Expected: "color " + bag.colorList(), Found: color}
+		}
+
+		subset[color] += number
+
+		if !c.Accept(",") {
+			return subset, nil
+		}
+	}
+}
+
+// Part1 sums the IDs of the games possible with 12 red, 13 green and 14 blue cubes.
+// It stops with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, parsed []Game) (int, error) {
+	return Part1With(ctx, parsed, Standard)
+}
+
+// Part1With sums the IDs of the games possible with the cubes of bag. A game
+// that shows a color the bag does not hold is impossible.
+func Part1With(ctx context.Context, parsed []Game, bag Bag) (int, error) {
+	sum := 0
+	for i, it := range parsed {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "games", i, len(parsed))
+
+		ok := true
+		for _, curr := range it.Subsets {
+			for color, n := range curr {
+				ok = ok && n <= bag.Cubes[color]
+			}
+		}
+
+		if ok {
+			sum += it.ID
+		}
+	}
+
+	return sum, nil
+}
+
+// Part2 sums the power of the fewest cubes needed to make every game possible.
+// It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, parsed []Game) (int, error) {
+	return Part2With(ctx, parsed, Standard)
+}
+
+// Part2With is Part2 with the colors of bag: the power of a game is the
+// product of the fewest cubes of each of them, so a game that shows none of
+// one of the bag's colors has no power. Unknown colors do not count.
+func Part2With(ctx context.Context, parsed []Game, bag Bag) (int, error) {
+	sum := 0
+	for i, game := range parsed {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "games", i, len(parsed))
+
+		fewest := make(map[string]int, len(bag.Cubes))
+		for _, curr := range game.Subsets {
+			for color, n := range curr {
+				fewest[color] = max(fewest[color], n)
+			}
+		}
+
+		power := 1
+		for color := range bag.Cubes {
+			power *= fewest[color]
+		}
+
+		sum += power
+	}
+
+	return sum, nil
+}
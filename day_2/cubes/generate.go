@@ -0,0 +1,36 @@
+package cubes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes scale times 100 random games of up to six draws of up to
+// 20 cubes of each color.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	colors := []string{"red", "green", "blue"}
+
+	bw := bufio.NewWriter(w)
+	for game := 1; game <= gen.Count(100, scale); game++ {
+		draws := make([]string, gen.Between(rng, 1, 6))
+		for i := range draws {
+			rng.Shuffle(len(colors), func(i, j int) { colors[i], colors[j] = colors[j], colors[i] })
+
+			cubes := make([]string, gen.Between(rng, 1, len(colors)))
+			for j := range cubes {
+				cubes[j] = fmt.Sprintf("%d %s", gen.Between(rng, 1, 20), colors[j])
+			}
+
+			draws[i] = strings.Join(cubes, ", ")
+		}
+
+		fmt.Fprintf(bw, "Game %d: %s\n", game, strings.Join(draws, "; "))
+	}
+
+	return bw.Flush()
+}
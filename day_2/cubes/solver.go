@@ -0,0 +1,44 @@
+package cubes
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{Day: 2, Title: "Cube Conundrum", Dir: "day_2", New: New, Version: "2", Examples: examples, Generate: Generate})
+}
+
+// New returns an aoc.Solver for day 2.
+func New() aoc.Solver {
+	return &solver{bag: Standard}
+}
+
+// NewWith returns an aoc.Solver for day 2 that plays the games with bag.
+func NewWith(bag Bag) aoc.Solver {
+	return &solver{bag: bag}
+}
+
+type solver struct {
+	games []Game
+	bag   Bag
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.games, err = ParseWith(strings.NewReader(input), s.bag)
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1With(ctx, s.games, s.bag)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2With(ctx, s.games, s.bag)
+}
@@ -1,116 +1,90 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
-	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/day_2/cubes"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
 )
 
 func main() {
-	data := strings.TrimSpace(readLocalInput())
-	parsed := parseData(data)
-	firstPart := solutionPart1(parsed)
-	secPart := solutionPart2(parsed)
-
-	fmt.Println("Part 1 count:", firstPart)
-	fmt.Println("Part 2 count:", secPart)
-}
-
-type subset struct {
-	Red   int
-	Green int
-	Blue  int
-}
-
-type gameData struct {
-	Game    int
-	Subsets []subset
-}
-
-func readLocalInput() string {
-	filePath := "input.txt"
-	content, err := os.ReadFile(filePath)
-
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	limits := flag.String("bag", "", `cubes in the bag, such as "12 red, 13 green, 14 blue" (default the puzzle's)`)
+	bagFile := flag.String("bag-file", "", "JSON file with the cubes in the bag; -bag overrides its cubes")
+	allowUnknown := flag.Bool("allow-unknown", false, "accept colors the bag does not hold; games showing them are impossible")
+	flag.Parse()
+
+	bag, err := loadBag(*limits, *bagFile)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-
-	return string(content)
-}
-
-func parseData(data string) []gameData {
-	lines := strings.Split(data, "\n")
-	parsed := make([]gameData, len(lines))
-
-	for i, line := range lines {
-		sets := strings.Split(line, ":")
-		gameNumber, _ := strconv.Atoi(strings.Fields(sets[0])[1])
-		subsets := make([]subset, 0)
-
-		for _, sub := range strings.Split(sets[1], ";") {
-			oneSubset := make(map[string]int)
-			for _, c := range strings.Split(sub, ",") {
-				c = strings.TrimSpace(c)
-				parts := strings.Split(c, " ")
-				number, _ := strconv.Atoi(parts[0])
-				color := parts[1]
-				oneSubset[color] = number
-			}
-
-			subsets = append(subsets, subset{
-				Red:   oneSubset["red"],
-				Green: oneSubset["green"],
-				Blue:  oneSubset["blue"],
-			})
+	bag.AllowUnknown = bag.AllowUnknown || *allowUnknown
+
+	if *format != output.Text {
+		d, _ := aoc.Lookup(2)
+		d.New = func() aoc.Solver { return cubes.NewWith(bag) }
+		if err := output.Run(os.Stdout, *format, d, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 
-		parsed[i] = gameData{
-			Game:    gameNumber,
-			Subsets: subsets,
-		}
+		return
 	}
 
-	return parsed
-}
-
-func solutionPart1(parsed []gameData) int {
-	sum := 0
-	for _, it := range parsed {
-		ok := true
+	err = input.Each(*path, os.Stdout, func(r io.Reader) error {
+		parsed, err := cubes.ParseWith(r, bag)
+		if err != nil {
+			return err
+		}
 
-		for _, curr := range it.Subsets {
-			ok = ok && curr.Red <= 12 && curr.Green <= 13 && curr.Blue <= 14
+		firstPart, err := cubes.Part1With(context.Background(), parsed, bag)
+		if err != nil {
+			return err
 		}
 
-		if ok {
-			sum += it.Game
+		secPart, err := cubes.Part2With(context.Background(), parsed, bag)
+		if err != nil {
+			return err
 		}
-	}
 
-	return sum
-}
+		fmt.Println("Part 1 count:", firstPart)
+		fmt.Println("Part 2 count:", secPart)
 
-func solutionPart2(parsed []gameData) int {
-	sum := 0
-	for _, game := range parsed {
-		max := subset{Red: 0, Green: 0, Blue: 0}
-		for _, curr := range game.Subsets {
-			if curr.Red > max.Red {
-				max.Red = curr.Red
-			}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
 
-			if curr.Green > max.Green {
-				max.Green = curr.Green
-			}
+// loadBag returns the bag of the -bag and -bag-file flags, the puzzle's when
+// neither is set.
+func loadBag(limits, path string) (cubes.Bag, error) {
+	bag := cubes.Standard
+	if path != "" {
+		var err error
+		if bag, err = cubes.LoadBag(path); err != nil {
+			return cubes.Bag{}, err
+		}
+	}
 
-			if curr.Blue > max.Blue {
-				max.Blue = curr.Blue
-			}
+	if limits != "" {
+		inline, err := cubes.ParseBag(limits)
+		if err != nil {
+			return cubes.Bag{}, err
 		}
 
-		sum += max.Red * max.Green * max.Blue
+		bag.Cubes = inline.Cubes
 	}
 
-	return sum
+	return bag, nil
 }
@@ -0,0 +1,138 @@
+// Package gears solves day 3 of Advent of Code 2023, "Gear Ratios".
+package gears
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Schematic is the engine schematic.
+type Schematic = grid.Grid[rune]
+
+// Parse reads an engine schematic from r.
+func Parse(r io.Reader) (*Schematic, error) {
+	return grid.Parse(r, "")
+}
+
+// Part1 sums every part number adjacent to a symbol. It stops with
+// ctx.Err() once ctx is done.
+func Part1(ctx context.Context, schematic *Schematic) (int, error) {
+	sum := 0
+	for y := 0; y < schematic.Height; y++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "rows", y, schematic.Height)
+
+		line := append(schematic.Row(y), '.')
+
+		var number string
+		valid := false
+
+		for x := 0; x < len(line); x++ {
+			if isDigit(line[x]) {
+				number += string(line[x])
+				if checkAdjacent(schematic, grid.Point{X: x, Y: y}, isSymbol) {
+					valid = true
+				}
+			} else {
+				if valid && number != "" {
+					n, _ := strconv.Atoi(number)
+					sum += n
+				}
+
+				number = ""
+				valid = false
+			}
+		}
+	}
+
+	return sum, nil
+}
+
+// Part2 sums the gear ratios of the schematic. A gear is a '*' adjacent to
+// exactly two part numbers and its ratio is their product; a '*' touching
+// one number or more than two is not a gear. It stops with ctx.Err() once
+// ctx is done.
+func Part2(ctx context.Context, schematic *Schematic) (int, error) {
+	sum := 0
+	gears := grid.FindAll(schematic, '*')
+	for i, p := range gears {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "gears", i, len(gears))
+		sum += calculateGearRatio(schematic, p)
+	}
+
+	return sum, nil
+}
+
+func isDigit(c rune) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isSymbol(c rune) bool {
+	return !isDigit(c) && c != '.'
+}
+
+func checkAdjacent(schematic *Schematic, p grid.Point, checkFunc func(rune) bool) bool {
+	for _, n := range schematic.Neighbors8(p) {
+		if checkFunc(schematic.At(n)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns the product of the part numbers adjacent to p when there are
+// exactly two of them, and 0 otherwise.
+func calculateGearRatio(schematic *Schematic, p grid.Point) int {
+	// a number is known by where it starts: one that touches p on several
+	// cells counts once, and two equal numbers count twice.
+	numbers := make(map[grid.Point]int)
+	for _, q := range schematic.Neighbors8(p) {
+		if start, n, ok := extractNumber(schematic.Row(q.Y), q.X); ok {
+			numbers[grid.Point{X: start, Y: q.Y}] = n
+		}
+	}
+
+	if len(numbers) != 2 {
+		return 0
+	}
+
+	ratio := 1
+	for _, n := range numbers {
+		ratio *= n
+	}
+
+	return ratio
+}
+
+// returns where the number through s[x] starts and its value, or false when
+// s[x] is not a digit.
+func extractNumber(s []rune, x int) (start, n int, ok bool) {
+	if x < 0 || x >= len(s) || !isDigit(s[x]) {
+		return 0, 0, false
+	}
+
+	start, end := x, x+1
+	for start > 0 && isDigit(s[start-1]) {
+		start--
+	}
+
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+
+	n, _ = strconv.Atoi(string(s[start:end]))
+
+	return start, n, true
+}
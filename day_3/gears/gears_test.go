@@ -0,0 +1,39 @@
+package gears_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_3/gears"
+)
+
+func TestPart2(t *testing.T) {
+	tests := []struct {
+		name      string
+		schematic string
+		want      int
+	}{
+		{"two numbers", "12.\n.*.\n..3", 36},
+		{"three numbers", "1.2\n.*.\n3..", 0},
+		{"one number", "12.\n.*.\n...", 0},
+		{"number touching twice", "123\n.*.\n4..", 492},
+		{"equal numbers", "5*5", 25},
+		{"zero", "0*7", 0},
+		{"two gears sharing a number", "2.3\n*.*\n.4.", 8 + 12},
+		{"example", "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598..", 467835},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schematic, err := gears.Parse(strings.NewReader(tt.schematic))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, err := gears.Part2(context.Background(), schematic); err != nil || got != tt.want {
+				t.Errorf("Part2 = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gears
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+const symbols = "*#+$/@%=-&"
+
+// Generate writes a random schematic, 140 by 140 tiles for a scale of 1,
+// of numbers up to 999 with symbols, many of them gears, scattered between
+// them.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	side := gen.Side(140, scale)
+
+	bw := bufio.NewWriter(w)
+	row := make([]byte, side)
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; {
+			switch r := rng.Intn(100); {
+			case r < 8:
+				number := strconv.Itoa(gen.Between(rng, 1, 999))
+				if x+len(number) > side {
+					row[x] = '.'
+					x++
+					continue
+				}
+
+				x += copy(row[x:], number)
+				if x < side {
+					row[x] = '.'
+					x++
+				}
+			case r < 12:
+				row[x] = '*'
+				x++
+			case r < 15:
+				row[x] = gen.Pick(rng, symbols)
+				x++
+			default:
+				row[x] = '.'
+				x++
+			}
+		}
+
+		bw.Write(row)
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,38 @@
+package gears
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{Day: 3, Title: "Gear Ratios", Dir: "day_3", New: New, Version: "2", Examples: examples, Generate: Generate})
+}
+
+// New returns an aoc.Solver for day 3.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	schematic *Schematic
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.schematic, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.schematic)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.schematic)
+}
@@ -1,148 +1,54 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
-	"strings"
-)
 
-var square = [][]int{
-	{-1, -1},
-	{0, -1},
-	{1, -1},
-	{-1, 0},
-	{1, 0},
-	{-1, 1},
-	{0, 1},
-	{1, 1},
-}
+	"github.com/unkn0wn-root/advent_of_code_2023/day_3/gears"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
+)
 
 func main() {
-	inputFile := readLocalInput()
-	fmt.Println(solutionPartOne(inputFile))
-	fmt.Println(solutionPartTwo(inputFile))
-}
-
-// read and split input file for each processing line instead of doing it in each func
-func readLocalInput() []string {
-	filePath := "input.txt"
-	content, err := os.ReadFile(filePath)
-
-	if err != nil {
-		panic(err)
-	}
-
-	return strings.Split(string(content), "\n")
-}
-
-func solutionPartOne(inputString []string) int {
-	sum := 0
-	for y, line := range inputString {
-		line = strings.TrimSpace(line) + "."
-
-		var number string
-		valid := false
-
-		for x := 0; x < len(line); x++ {
-			if isDigit(line[x]) {
-				number += string(line[x])
-				if checkAdjacent(inputString, x, y, isSymbol) {
-					valid = true
-				}
-			} else {
-				if valid && number != "" {
-					n, _ := strconv.Atoi(number)
-					sum += n
-				}
-
-				number = ""
-				valid = false
-			}
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	flag.Parse()
+
+	if *format != output.Text {
+		if err := output.Day(os.Stdout, *format, 3, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-	}
-
-	return sum
-}
-
-func solutionPartTwo(inputString []string) int {
-	sum := 0
-	for y, line := range inputString {
-		line = strings.TrimSpace(line) + "."
 
-		for x := 0; x < len(line); x++ {
-			if line[x] == '*' {
-				n := calculateGearRatio(inputString, x, y)
-				sum += n
-			}
-		}
+		return
 	}
 
-	return sum
-}
-
-func isDigit(c byte) bool {
-	return '0' <= c && c <= '9'
-}
-
-func isSymbol(c byte) bool {
-	return !isDigit(c) && c != '.'
-}
-
-func checkAdjacent(lines []string, x, y int, checkFunc func(byte) bool) bool {
-	for _, d := range square {
-		dx, dy := d[0], d[1]
-
-		if isValidCoordinate(x+dx, y+dy, lines) && checkFunc(lines[y+dy][x+dx]) {
-			return true
+	err := input.Each(*path, os.Stdout, func(r io.Reader) error {
+		schematic, err := gears.Parse(r)
+		if err != nil {
+			return err
 		}
-	}
-
-	return false
-}
-
-func isValidCoordinate(x, y int, lines []string) bool {
-	return y >= 0 && y < len(lines) && x >= 0 && x < len(lines[y])
-}
-
-func calculateGearRatio(lines []string, x, y int) int {
-	n, n1 := 0, 0
-	for _, d := range square {
-		dx, dy := d[0], d[1]
 
-		if isValidCoordinate(x+dx, y+dy, lines) {
-			n = extractNumber(lines[y+dy], x+dx)
-			if n > 0 {
-				if n1 == 0 {
-					n1 = n
-					continue
-				}
-
-				if n1 != n {
-					return n * n1
-				}
-			}
+		part1, err := gears.Part1(context.Background(), schematic)
+		if err != nil {
+			return err
 		}
-	}
 
-	return 0
-}
-
-func extractNumber(s string, x int) int {
-	if x < 0 || x >= len(s) || !isDigit(s[x]) {
-		return -1
-	}
+		part2, err := gears.Part2(context.Background(), schematic)
+		if err != nil {
+			return err
+		}
 
-	number := string(s[x])
-	for i := 1; x+i < len(s) && isDigit(s[x+i]); i++ {
-		number += string(s[x+i])
-	}
+		fmt.Println(part1)
+		fmt.Println(part2)
 
-	for i := 1; x-i >= 0 && isDigit(s[x-i]); i++ {
-		number = string(s[x-i]) + number
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	n, _ := strconv.Atoi(number)
-
-	return n
 }
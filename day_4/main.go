@@ -1,90 +1,54 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"flag"
 	"fmt"
-	"math"
+	"io"
 	"os"
-	"strconv"
-	"strings"
-)
-
-func numSet(s string) map[int]struct{} {
-	set := make(map[int]struct{})
-	for _, numStr := range strings.Fields(s) {
-		num := parseInt(numStr)
-		set[num] = struct{}{}
-	}
 
-	return set
-}
+	"github.com/unkn0wn-root/advent_of_code_2023/day_4/scratchcards"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
+)
 
-func interSizeCount(set1, set2 map[int]struct{}) int {
-	count := 0
-	for num := range set1 {
-		if _, exists := set2[num]; exists {
-			count++
+func main() {
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	flag.Parse()
+
+	if *format != output.Text {
+		if err := output.Day(os.Stdout, *format, 4, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-	}
-
-	return count
-}
-
-func parseInt(s string) int {
-	num, _ := strconv.Atoi(s)
-	return num
-}
-
-func readSolutionLines(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
 
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+		return
 	}
 
-	return lines, scanner.Err()
-}
-
-func solve(lines []string) (part1, part2 int) {
-	counts := make(map[int]int)
-	for l, line := range lines {
-		winners, inHand, _ := strings.Cut(line[9:], "|")
-		copies := interSizeCount(numSet(inHand), numSet(winners))
-
-		part1 += int(math.Pow(2, float64(copies-1)))
-		part2++
-
-		card := l + 1
-		counts[card]++
-		count := counts[card]
+	err := input.Each(*path, os.Stdout, func(r io.Reader) error {
+		cards, err := scratchcards.Parse(r)
+		if err != nil {
+			return err
+		}
 
-		for x := 1; x <= copies; x++ {
-			counts[card+x] += count
-			part2 += count
+		part1, err := scratchcards.Part1(context.Background(), cards)
+		if err != nil {
+			return err
 		}
 
-		delete(counts, card)
-	}
+		part2, err := scratchcards.Part2(context.Background(), cards)
+		if err != nil {
+			return err
+		}
 
-	return part1, part2
-}
+		fmt.Println("Part 1 count:", part1)
+		fmt.Println("Part 2 count:", part2)
 
-func main() {
-	lines, err := readSolutionLines("input.txt")
+		return nil
+	})
 	if err != nil {
-		fmt.Println("Error reading local solution file:", err)
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	part1, part2 := solve(lines)
-
-	fmt.Println("Part 1 count:", part1)
-	fmt.Println("Part 2 count:", part2)
 }
@@ -0,0 +1,53 @@
+package scratchcards
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes scale times 200 random cards of 10 winning numbers and 25
+// numbers in hand, all below 100. Most cards win nothing, so that the number
+// of copies in part two stays bounded however many cards there are.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	const winning, inHand = 10, 25
+
+	cards := gen.Count(200, scale)
+	width := len(strconv.Itoa(cards))
+
+	bw := bufio.NewWriter(w)
+	for card := 1; card <= cards; card++ {
+		numbers := rng.Perm(99)[:winning+inHand]
+		for i := range numbers {
+			numbers[i]++
+		}
+
+		matches := 0
+		if rng.Intn(100) < 15 {
+			matches = min(gen.Between(rng, 1, winning), cards-card)
+		}
+
+		// numbers[:winning] are the winning numbers; the numbers in hand
+		// are the first matches of them and numbers that do not win.
+		hand := append(append([]int(nil), numbers[:matches]...), numbers[winning:winning+inHand-matches]...)
+		rng.Shuffle(len(hand), func(i, j int) { hand[i], hand[j] = hand[j], hand[i] })
+
+		fmt.Fprintf(bw, "Card %*d:", width, card)
+		for _, n := range numbers[:winning] {
+			fmt.Fprintf(bw, " %2d", n)
+		}
+
+		bw.WriteString(" |")
+		for _, n := range hand {
+			fmt.Fprintf(bw, " %2d", n)
+		}
+
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
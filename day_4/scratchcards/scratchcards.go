@@ -0,0 +1,143 @@
+// Package scratchcards solves day 4 of Advent of Code 2023, "Scratchcards".
+package scratchcards
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Card is one scratchcard: the winning numbers and the numbers in hand.
+type Card struct {
+	Winning []int
+	InHand  []int
+}
+
+// Matches returns how many numbers in hand are winning numbers.
+func (c Card) Matches() int {
+	return interSizeCount(numSet(c.InHand), numSet(c.Winning))
+}
+
+func numSet(nums []int) map[int]struct{} {
+	set := make(map[int]struct{})
+	for _, num := range nums {
+		set[num] = struct{}{}
+	}
+
+	return set
+}
+
+func interSizeCount(set1, set2 map[int]struct{}) int {
+	count := 0
+	for num := range set1 {
+		if _, exists := set2[num]; exists {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Parse reads the pile of scratchcards from r.
+func Parse(r io.Reader) ([]Card, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]Card, 0, len(lines))
+	for i, line := range lines {
+		card, err := parseCard(parse.NewCursor(i+1, line))
+		if err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+// parses a line of the form "Card 1: 41 48 83 | 83 86  6".
+func parseCard(c *parse.Cursor) (Card, error) {
+	if err := c.Expect("Card"); err != nil {
+		return Card{}, err
+	}
+
+	c.SkipSpaces()
+	if _, err := c.Int(); err != nil {
+		return Card{}, err
+	}
+
+	if err := c.Expect(":"); err != nil {
+		return Card{}, err
+	}
+
+	winners, err := c.Ints()
+	if err != nil {
+		return Card{}, err
+	}
+
+	c.SkipSpaces()
+	if err := c.Expect("|"); err != nil {
+		return Card{}, err
+	}
+
+	inHand, err := c.Ints()
+	if err != nil {
+		return Card{}, err
+	}
+
+	if err := c.End(); err != nil {
+		return Card{}, err
+	}
+
+	return Card{Winning: winners, InHand: inHand}, nil
+}
+
+// Part1 sums the points of every card. It stops with ctx.Err() once ctx is
+// done.
+func Part1(ctx context.Context, cards []Card) (int, error) {
+	part1, _, err := solve(ctx, cards)
+	return part1, err
+}
+
+// Part2 counts the scratchcards held once every won copy has been processed.
+// It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, cards []Card) (int, error) {
+	_, part2, err := solve(ctx, cards)
+	return part2, err
+}
+
+func solve(ctx context.Context, cards []Card) (part1, part2 int, err error) {
+	counts := make(map[int]int)
+	for l, c := range cards {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+
+		aoc.ReportProgress(ctx, "cards", l, len(cards))
+
+		copies := c.Matches()
+
+		part1 += int(math.Pow(2, float64(copies-1)))
+		part2++
+
+		card := l + 1
+		counts[card]++
+		count := counts[card]
+
+		for x := 1; x <= copies; x++ {
+			counts[card+x] += count
+			part2 += count
+		}
+
+		delete(counts, card)
+	}
+
+	return part1, part2, nil
+}
@@ -0,0 +1,218 @@
+// Package almanac solves day 5 of Advent of Code 2023, "If You Give A Seed A Fertilizer".
+package almanac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/interval"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Almanac is the parsed puzzle input: the seeds to plant and the maps
+// that lead from a seed to its location.
+type Almanac struct {
+	Seeds []int
+	Maps  []SeedRequirement
+}
+
+// RequirementRange is one line of a map: Length ids starting at Source map
+// to the ids starting at Destination.
+type RequirementRange struct {
+	Source      int
+	Destination int
+	Length      int
+	Multiplier  int
+}
+
+// SeedRequirement is a whole "<from>-to-<to> map" section.
+type SeedRequirement struct {
+	FromDest     string
+	ToDest       string
+	Requirements []RequirementRange
+}
+
+// returns the ids the map sends ids to: the parts covered by a range move
+// with it, everything else keeps its id.
+func (s *SeedRequirement) mapIds(ids interval.Set) interval.Set {
+	var mapped interval.Set
+	for _, req := range s.Requirements {
+		source := interval.NewSet(interval.Of(req.Source, req.Length))
+		mapped = mapped.Union(ids.Intersect(source).Shift(req.Destination - req.Source))
+		ids = ids.Difference(source)
+	}
+
+	return mapped.Union(ids)
+}
+
+// getSeeds parses the "seeds: 79 14 55 13" line.
+func getSeeds(c *parse.Cursor) ([]int, error) {
+	if err := c.Expect("seeds:"); err != nil {
+		return nil, err
+	}
+
+	out, err := c.Ints()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, c.Errorf("seed number")
+	}
+
+	return out, c.End()
+}
+
+// extracts "from" and "to" destinations from a "<from>-to-<to> map:" line.
+func getMap(c *parse.Cursor) (fromDest string, toDest string, err error) {
+	if fromDest, err = c.Word(); err != nil {
+		return "", "", err
+	}
+
+	if err = c.Expect("-to-"); err != nil {
+		return "", "", err
+	}
+
+	if toDest, err = c.Word(); err != nil {
+		return "", "", err
+	}
+
+	if err = c.Expect(" map:"); err != nil {
+		return "", "", err
+	}
+
+	return fromDest, toDest, c.End()
+}
+
+// extracts RequirementRange from a "<destination> <source> <length>" line.
+func getRangeRequirements(c *parse.Cursor) (RequirementRange, error) {
+	var values [3]int
+	for i := range values {
+		c.SkipSpaces()
+
+		n, err := c.Int()
+		if err != nil {
+			return RequirementRange{}, err
+		}
+
+		values[i] = n
+	}
+
+	if err := c.End(); err != nil {
+		return RequirementRange{}, err
+	}
+
+	return RequirementRange{
+		Source:      values[1],
+		Destination: values[0],
+		Length:      values[2],
+	}, nil
+}
+
+// extracts SeedRequirements from the input lines following the seeds.
+func getSeedRequirements(data []string) ([]SeedRequirement, error) {
+	var out []SeedRequirement
+	startIndex := 2
+
+	for startIndex < len(data) {
+		fromDest, toDest, err := getMap(parse.NewCursor(startIndex+1, data[startIndex]))
+		if err != nil {
+			return nil, err
+		}
+
+		seedReq := SeedRequirement{FromDest: fromDest, ToDest: toDest}
+
+		startIndex++
+		for startIndex < len(data) && len(data[startIndex]) > 0 {
+			req, err := getRangeRequirements(parse.NewCursor(startIndex+1, data[startIndex]))
+			if err != nil {
+				return nil, err
+			}
+
+			seedReq.Requirements = append(seedReq.Requirements, req)
+			startIndex++
+		}
+
+		out = append(out, seedReq)
+		startIndex++
+	}
+
+	return out, nil
+}
+
+// Parse reads an almanac from r.
+func Parse(r io.Reader) (*Almanac, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds, err := getSeeds(parse.NewCursor(1, lines[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) > 1 && lines[1] != "" {
+		return nil, &parse.Error{Line: 2, Expected: "blank line", Found: lines[1]}
+	}
+
+	maps, err := getSeedRequirements(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Almanac{Seeds: seeds, Maps: maps}, nil
+}
+
+// Part1 returns the lowest location of any of the listed seeds. It stops
+// with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, a *Almanac) (int, error) {
+	seeds := make([]interval.Interval, len(a.Seeds))
+	for i, seed := range a.Seeds {
+		seeds[i] = interval.Of(seed, 1)
+	}
+
+	return lowestLocation(ctx, interval.NewSet(seeds...), a.Maps)
+}
+
+// Part2 returns the lowest location when the seeds line lists pairs of
+// range start and length. It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, a *Almanac) (int, error) {
+	if len(a.Seeds)%2 != 0 {
+		return 0, fmt.Errorf("almanac: %d seed numbers do not form start/length pairs", len(a.Seeds))
+	}
+
+	var seeds []interval.Interval
+	for i := 0; i < len(a.Seeds); i += 2 {
+		seeds = append(seeds, interval.Of(a.Seeds[i], a.Seeds[i+1]))
+	}
+
+	return lowestLocation(ctx, interval.NewSet(seeds...), a.Maps)
+}
+
+// errNoSeeds is returned when there is no seed to plant, such as when every
+// range of seeds is empty, and so no lowest location.
+var errNoSeeds = errors.New("almanac: no seeds to plant")
+
+// maps whole ranges of seeds through every map at once and returns the lowest location reached.
+func lowestLocation(ctx context.Context, seeds interval.Set, seedRequirements []SeedRequirement) (int, error) {
+	for i, seedReq := range seedRequirements {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "maps", i, len(seedRequirements))
+		seeds = seedReq.mapIds(seeds)
+	}
+
+	lowest, ok := seeds.Min()
+	if !ok {
+		return 0, errNoSeeds
+	}
+
+	return lowest, nil
+}
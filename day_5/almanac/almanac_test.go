@@ -0,0 +1,65 @@
+package almanac_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_5/almanac"
+)
+
+var parts = []struct {
+	name string
+	part func(context.Context, *almanac.Almanac) (int, error)
+}{
+	{"Part1", almanac.Part1},
+	{"Part2", almanac.Part2},
+	{"Part1BruteForce", almanac.Part1BruteForce},
+	{"Part2BruteForce", almanac.Part2BruteForce},
+}
+
+func TestParts(t *testing.T) {
+	example, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := almanac.Parse(strings.NewReader(string(example)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{35, 46, 35, 46}
+	for i, p := range parts {
+		if got, err := p.part(context.Background(), a); err != nil || got != want[i] {
+			t.Errorf("%s = %d, %v, want %d", p.name, got, err, want[i])
+		}
+	}
+}
+
+func TestPartsRejectNoSeeds(t *testing.T) {
+	tests := []struct {
+		name  string
+		seeds []int
+	}{
+		{"no seeds", nil},
+		{"empty ranges", []int{79, 0, 55, 0}},
+	}
+
+	maps := []almanac.SeedRequirement{{Requirements: []almanac.RequirementRange{{Destination: 52, Source: 50, Length: 48}}}}
+
+	for _, tt := range tests {
+		for _, p := range parts {
+			// a seed list without lengths has seeds for part one.
+			if len(tt.seeds) > 0 && strings.HasPrefix(p.name, "Part1") {
+				continue
+			}
+
+			a := &almanac.Almanac{Seeds: tt.seeds, Maps: maps}
+			if got, err := p.part(context.Background(), a); err == nil {
+				t.Errorf("%s with %s = %d, want an error", p.name, tt.name, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package almanac
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+// Part1BruteForce is Part1 mapping one seed at a time through every map.
+// It stops with ctx.Err() once ctx is done.
+func Part1BruteForce(ctx context.Context, a *Almanac) (int, error) {
+	if len(a.Seeds) == 0 {
+		return 0, errNoSeeds
+	}
+
+	lowest := math.MaxInt
+	for i, seed := range a.Seeds {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		lowest = min(lowest, location(seed, a.Maps))
+	}
+
+	return lowest, nil
+}
+
+// how many seeds Part2BruteForce maps between checks for cancellation.
+const checkEvery = 1 << 16
+
+// Part2BruteForce is Part2 mapping every seed of every range, one at a
+// time, with a goroutine per range. It takes minutes on a real input and
+// stops with ctx.Err() once ctx is done.
+func Part2BruteForce(ctx context.Context, a *Almanac) (int, error) {
+	if len(a.Seeds)%2 != 0 {
+		return 0, fmt.Errorf("almanac: %d seed numbers do not form start/length pairs", len(a.Seeds))
+	}
+
+	seeds := 0
+	for i := 1; i < len(a.Seeds); i += 2 {
+		seeds += max(a.Seeds[i], 0)
+	}
+
+	if seeds == 0 {
+		return 0, errNoSeeds
+	}
+
+	progress := aoc.NewCounter(ctx, "seeds", seeds)
+
+	lowest := make([]int, len(a.Seeds)/2)
+	var wg sync.WaitGroup
+	for i := range lowest {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			start, length := a.Seeds[2*i], a.Seeds[2*i+1]
+			lowest[i] = math.MaxInt
+			for n := 0; n < length; n++ {
+				lowest[i] = min(lowest[i], location(start+n, a.Maps))
+
+				if n%checkEvery == checkEvery-1 {
+					if ctx.Err() != nil {
+						return
+					}
+
+					progress.Add(checkEvery)
+				}
+			}
+
+			progress.Add(length % checkEvery)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return slices.Min(lowest), nil
+}
+
+// location maps a seed through every map.
+func location(id int, maps []SeedRequirement) int {
+	for _, m := range maps {
+		id = m.mapId(id)
+	}
+
+	return id
+}
+
+// returns the id the map sends id to: the first range covering it moves it,
+// otherwise it keeps its id.
+func (s *SeedRequirement) mapId(id int) int {
+	for _, req := range s.Requirements {
+		if req.Source <= id && id < req.Source+req.Length {
+			return req.Destination + id - req.Source
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,58 @@
+package almanac
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// categories are the steps from a seed to its location.
+var categories = []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+
+// Generate writes a random almanac of scale times 10 seed ranges and maps of
+// scale times 30 ranges each, with ids below 1<<32 as in the real inputs.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	const ids = 1 << 32
+
+	bw := bufio.NewWriter(w)
+
+	seeds := make([]string, 0, 2*gen.Count(10, scale))
+	for i := cap(seeds) / 2; i > 0; i-- {
+		start := rng.Int63n(ids - 1<<28)
+		seeds = append(seeds, fmt.Sprint(start), fmt.Sprint(1+rng.Int63n(1<<28-1)))
+	}
+
+	fmt.Fprintf(bw, "seeds: %s\n", strings.Join(seeds, " "))
+
+	for i := 0; i+1 < len(categories); i++ {
+		fmt.Fprintf(bw, "\n%s-to-%s map:\n", categories[i], categories[i+1])
+
+		// pairs of cuts bound the source ranges, so that they never overlap.
+		cuts := make([]int64, 2*gen.Count(30, scale))
+		for j := range cuts {
+			cuts[j] = rng.Int63n(ids)
+		}
+
+		sort.Slice(cuts, func(a, b int) bool { return cuts[a] < cuts[b] })
+		rng.Shuffle(len(cuts)/2, func(a, b int) {
+			cuts[2*a], cuts[2*b] = cuts[2*b], cuts[2*a]
+			cuts[2*a+1], cuts[2*b+1] = cuts[2*b+1], cuts[2*a+1]
+		})
+
+		for j := 0; j < len(cuts); j += 2 {
+			source, length := cuts[j], cuts[j+1]-cuts[j]
+			if length == 0 {
+				continue
+			}
+
+			fmt.Fprintf(bw, "%d %d %d\n", rng.Int63n(ids-length+1), source, length)
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,56 @@
+package almanac
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{
+		Day: 5, Title: "If You Give A Seed A Fertilizer", Dir: "day_5", New: New, Version: "1", Examples: examples, Generate: Generate,
+		Alternates: []aoc.Alternate{
+			{Name: "brute-force", Parts: []int{1, 2}, New: func() aoc.Solver { return &bruteForce{} }},
+		},
+	})
+}
+
+// New returns an aoc.Solver for day 5.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	almanac *Almanac
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.almanac, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.almanac)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.almanac)
+}
+
+// bruteForce solves day 5 one seed at a time instead of one range at a time.
+type bruteForce struct {
+	solver
+}
+
+func (s *bruteForce) PartOne(ctx context.Context) (any, error) {
+	return Part1BruteForce(ctx, s.almanac)
+}
+
+func (s *bruteForce) PartTwo(ctx context.Context) (any, error) {
+	return Part2BruteForce(ctx, s.almanac)
+}
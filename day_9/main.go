@@ -1,98 +1,54 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
-	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_9/mirage"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
 )
 
-func readInputFile(filePath string) (*bufio.Scanner, *os.File, error) {
-	if file, err := os.Open(filePath); err != nil {
-		return nil, nil, err
-	} else {
-		return bufio.NewScanner(file), file, nil
-	}
-}
+func main() {
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	flag.Parse()
+
+	if *format != output.Text {
+		if err := output.Day(os.Stdout, *format, 9, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 
-func solve(scanner *bufio.Scanner) (int, int) {
-	sumPart1, sumPart2 := 0, 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		nums := stringToIntSlice(strings.Split(line, " "))
-		lastVals := getLastGenerationValues(nums)
-		firstVal, lastVal := calculateSums(lastVals)
-		sumPart1 += lastVal
-		sumPart2 += firstVal
+		return
 	}
 
-	return sumPart1, sumPart2
-}
-
-func getLastGenerationValues(nums []int) [][]int {
-	lastVals := [][]int{{nums[0], nums[len(nums)-1]}}
-	for {
-		newNums := make([]int, 0, len(nums)-1)
-		allZero := true
-		for i := 0; i < len(nums)-1; i++ {
-			diff := nums[i+1] - nums[i]
-			if diff != 0 {
-				allZero = false
-			}
-
-			newNums = append(newNums, diff)
+	err := input.Each(*path, os.Stdout, func(r io.Reader) error {
+		histories, err := mirage.Parse(r)
+		if err != nil {
+			return err
 		}
 
-		nums = newNums
-		lastVals = append(lastVals, []int{nums[0], nums[len(nums)-1]})
-
-		if allZero {
-			break
+		part1, err := mirage.Part1(context.Background(), histories)
+		if err != nil {
+			return err
 		}
-	}
 
-	return lastVals
-}
-
-func calculateSums(lastVals [][]int) (int, int) {
-	firstVal, lastVal := 0, 0
-	for i := len(lastVals) - 1; i >= 0; i-- {
-		previousVals := lastVals[i]
-		firstVal = previousVals[0] - firstVal
-		lastVal += previousVals[1]
-	}
-
-	return firstVal, lastVal
-}
-
-func stringToIntSlice(strs []string) []int {
-	nums := make([]int, len(strs))
-	for i, str := range strs {
-		num, err := strconv.Atoi(str)
+		part2, err := mirage.Part2(context.Background(), histories)
 		if err != nil {
-			fmt.Printf("Error converting string to int: %v\n", err)
-			return nil
+			return err
 		}
 
-		nums[i] = num
-	}
+		fmt.Println("Part 1 (next value, each):", part1)
+		fmt.Println("Part 2 (previous value, each):", part2)
 
-	return nums
-}
-
-func main() {
-	inputFilename := "input.txt"
-	scanner, file, err := readInputFile(inputFilename)
+		return nil
+	})
 	if err != nil {
-		fmt.Printf("Error opening file: %v\n", err)
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	defer file.Close()
-
-	sum1, sum2 := solve(scanner)
-
-	fmt.Println("Part 1 (next value, each):", sum1)
-	fmt.Println("Part 2 (previous value, each):", sum2)
 }
@@ -0,0 +1,42 @@
+package mirage
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes scale times 200 random histories of 21 values. Each is a
+// polynomial sequence of degree at most 12, so its differences reach zero
+// well before the end.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	const length = 21
+
+	bw := bufio.NewWriter(w)
+	for i := gen.Count(200, scale); i > 0; i-- {
+		// start with the first value of every row of differences, then sum
+		// them back up, one value of the history at a time.
+		diffs := make([]int, gen.Between(rng, 1, 12)+1)
+		for j := range diffs {
+			diffs[j] = gen.Between(rng, -9, 9)
+		}
+
+		for n := 0; n < length; n++ {
+			if n > 0 {
+				bw.WriteByte(' ')
+			}
+
+			bw.WriteString(strconv.Itoa(diffs[0]))
+			for j := 0; j+1 < len(diffs); j++ {
+				diffs[j] += diffs[j+1]
+			}
+		}
+
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
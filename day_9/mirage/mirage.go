@@ -0,0 +1,144 @@
+// Package mirage solves day 9 of Advent of Code 2023, "Mirage Maintenance".
+package mirage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// History is one line of the report: the values of a single reading over
+// time.
+type History struct {
+	Values []int
+
+	// ends holds the first and last value of Values and of every row of
+	// differences, as Parse found them while checking the history. It is nil
+	// for a History built by hand.
+	ends [][]int
+}
+
+// Parse reads the value histories from r, one per line.
+func Parse(r io.Reader) ([]History, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]History, 0, len(lines))
+	for i, line := range lines {
+		c := parse.NewCursor(i+1, line)
+		nums, err := c.Ints()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.End(); err != nil {
+			return nil, err
+		}
+
+		if len(nums) < 2 {
+			return nil, c.Errorf("history of at least two values")
+		}
+
+		ends, ok := getLastGenerationValues(nums)
+		if !ok {
+			return nil, &parse.Error{Line: i + 1, Expected: "history whose differences reach all zeros", Found: line}
+		}
+
+		histories = append(histories, History{Values: nums, ends: ends})
+	}
+
+	return histories, nil
+}
+
+// Part1 sums the extrapolated next value of every history. It stops with
+// ctx.Err() once ctx is done.
+func Part1(ctx context.Context, histories []History) (int, error) {
+	next, _, err := solve(ctx, histories)
+	return next, err
+}
+
+// Part2 sums the extrapolated previous value of every history. It stops
+// with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, histories []History) (int, error) {
+	_, previous, err := solve(ctx, histories)
+	return previous, err
+}
+
+func solve(ctx context.Context, histories []History) (int, int, error) {
+	sumPart1, sumPart2 := 0, 0
+	for i, h := range histories {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+
+		aoc.ReportProgress(ctx, "histories", i, len(histories))
+
+		lastVals, ok := h.ends, true
+		if lastVals == nil {
+			lastVals, ok = getLastGenerationValues(h.Values)
+		}
+
+		if !ok {
+			return 0, 0, fmt.Errorf("mirage: history %d: the differences of %v never reach all zeros", i+1, h.Values)
+		}
+
+		firstVal, lastVal := calculateSums(lastVals)
+		sumPart1 += lastVal
+		sumPart2 += firstVal
+	}
+
+	return sumPart1, sumPart2, nil
+}
+
+// getLastGenerationValues returns the first and last value of the history
+// and of every row of differences down to the first one of all zeros. ok is
+// false when the rows run out before one is all zeros.
+func getLastGenerationValues(nums []int) (lastVals [][]int, ok bool) {
+	if len(nums) == 0 {
+		return nil, false
+	}
+
+	lastVals = [][]int{{nums[0], nums[len(nums)-1]}}
+	for {
+		newNums := make([]int, 0, len(nums)-1)
+		allZero := true
+		for i := 0; i < len(nums)-1; i++ {
+			diff := nums[i+1] - nums[i]
+			if diff != 0 {
+				allZero = false
+			}
+
+			newNums = append(newNums, diff)
+		}
+
+		if len(newNums) == 0 {
+			return nil, false
+		}
+
+		nums = newNums
+		lastVals = append(lastVals, []int{nums[0], nums[len(nums)-1]})
+
+		if allZero {
+			break
+		}
+	}
+
+	return lastVals, true
+}
+
+func calculateSums(lastVals [][]int) (int, int) {
+	firstVal, lastVal := 0, 0
+	for i := len(lastVals) - 1; i >= 0; i-- {
+		previousVals := lastVals[i]
+		firstVal = previousVals[0] - firstVal
+		lastVal += previousVals[1]
+	}
+
+	return firstVal, lastVal
+}
@@ -0,0 +1,88 @@
+package mirage_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day_9/mirage"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+func TestParseRejectsMalformedHistories(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+	}{
+		{"differences run out", "0 3 6 9 12 15\n1 5 10", 2},
+		{"two values that differ", "1 2", 1},
+		{"single value", "0 3 6\n7", 2},
+		{"no values", "0 3 6\n \n1 3 5", 2},
+		{"not a number", "0 3 x", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mirage.Parse(strings.NewReader(tt.input))
+
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse(%q) error = %v, want a *parse.Error", tt.input, err)
+			}
+
+			if perr.Line != tt.line {
+				t.Errorf("Parse(%q) error at line %d, want line %d: %v", tt.input, perr.Line, tt.line, err)
+			}
+		})
+	}
+}
+
+func TestPartsRejectHistoriesWithoutZeros(t *testing.T) {
+	for _, values := range [][]int{{1, 5, 10}, {}, {7}} {
+		histories := []mirage.History{{Values: []int{0, 3, 6}}, {Values: values}}
+
+		if _, err := mirage.Part1(context.Background(), histories); err == nil {
+			t.Errorf("Part1(%v) succeeded, want an error", histories)
+		}
+
+		if _, err := mirage.Part2(context.Background(), histories); err == nil {
+			t.Errorf("Part2(%v) succeeded, want an error", histories)
+		}
+	}
+}
+
+func TestParts(t *testing.T) {
+	histories, err := mirage.Parse(strings.NewReader("0 3 6 9 12 15\n1 3 6 10 15 21\n10 13 16 21 30 45"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		part func(context.Context, []mirage.History) (int, error)
+		want int
+	}{
+		{"Part1", mirage.Part1, 114},
+		{"Part2", mirage.Part2, 2},
+	}
+
+	// histories built by hand work out their differences when they are
+	// solved rather than when they are parsed.
+	byHand := make([]mirage.History, len(histories))
+	for i, h := range histories {
+		byHand[i] = mirage.History{Values: h.Values}
+	}
+
+	for _, tt := range tests {
+		got, err := tt.part(context.Background(), histories)
+		if err != nil || got != tt.want {
+			t.Errorf("%s = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+
+		if got, err := tt.part(context.Background(), byHand); err != nil || got != tt.want {
+			t.Errorf("%s of histories built by hand = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package mirage
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{Day: 9, Title: "Mirage Maintenance", Dir: "day_9", New: New, Version: "1", Examples: examples, Generate: Generate})
+}
+
+// New returns an aoc.Solver for day 9.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	histories []History
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.histories, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.histories)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.histories)
+}
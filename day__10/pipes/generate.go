@@ -0,0 +1,53 @@
+package pipes
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Generate writes a random maze, 140 by 140 tiles for a scale of 1, holding
+// a single loop through S among stray pipes.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	side := gen.Side(140, scale)
+
+	maze := grid.New[byte](side, side)
+	maze.Each(func(p grid.Point, _ byte) {
+		maze.Set(p, gen.Pick(rng, ".|-LJ7F"))
+	})
+
+	// the loop is the outline of a random shape on the grid of cells whose
+	// corners are the tiles.
+	loop := gen.Outline(gen.Blob(rng, side-1, side-1, 0.6))
+	for i, p := range loop {
+		prev, next := loop[(i+len(loop)-1)%len(loop)], loop[(i+1)%len(loop)]
+		maze.Set(p, pipe(prev.Sub(p), next.Sub(p)))
+	}
+
+	if len(loop) > 0 {
+		maze.Set(loop[rng.Intn(len(loop))], 'S')
+	}
+
+	bw := bufio.NewWriter(w)
+	for y := 0; y < maze.Height; y++ {
+		bw.Write(maze.Row(y))
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// pipe returns the pipe connecting the directions a and b.
+func pipe(a, b grid.Point) byte {
+	for _, p := range []byte("|-LJ7F") {
+		ends := connections[rune(p)]
+		if ends[0] == a && ends[1] == b || ends[0] == b && ends[1] == a {
+			return p
+		}
+	}
+
+	panic("pipes: no pipe connects " + a.String() + " and " + b.String())
+}
@@ -0,0 +1,348 @@
+// Package pipes solves day 10 of Advent of Code 2023, "Pipe Maze".
+package pipes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/graph"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Maze is the sketch of the pipe field.
+type Maze = grid.Grid[rune]
+
+// zoo represents the visual representation and count for part 2
+type zoo struct {
+	v     string
+	count int
+}
+
+// Parse reads the maze sketch from r.
+func Parse(r io.Reader) (*Maze, error) {
+	return grid.Parse(r, ".|-LJ7FS")
+}
+
+// Part1 returns how many steps along the loop the farthest point is from the
+// start. It stops with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, m *Maze) (int, error) {
+	l, err := findLoop(ctx, m)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.farthest, nil
+}
+
+// Part2 returns how many tiles are enclosed by the loop. It stops with
+// ctx.Err() once ctx is done.
+func Part2(ctx context.Context, m *Maze) (int, error) {
+	l, err := findLoop(ctx, m)
+	if err != nil {
+		return 0, err
+	}
+
+	inside, _, err := findVisualArea(ctx, l, m)
+	if err != nil {
+		return 0, err
+	}
+
+	return inside, nil
+}
+
+// Part2Pick is Part2 by Pick's theorem: the area of the loop, found with the
+// shoelace formula, is the number of tiles enclosed plus half the tiles on
+// the loop, less one.
+func Part2Pick(ctx context.Context, m *Maze) (int, error) {
+	l, err := findLoop(ctx, m)
+	if err != nil {
+		return 0, err
+	}
+
+	tiles := l.walk(m)
+
+	area := 0
+	for i, p := range tiles {
+		q := tiles[(i+1)%len(tiles)]
+		area += p.X*q.Y - p.Y*q.X
+	}
+
+	if area < 0 {
+		area = -area
+	}
+
+	// area is twice the area of the loop.
+	return (area-len(tiles))/2 + 1, nil
+}
+
+// Render returns the loop drawn with box characters, tiles enclosed by it
+// highlighted, followed by the number of enclosed tiles on every row.
+func Render(m *Maze) ([]string, error) {
+	ctx := context.Background()
+
+	l, err := findLoop(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	_, visual, err := findVisualArea(ctx, l, m)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for i := 0; i < m.Height; i++ {
+		if v, ok := visual[i]; ok {
+			out = append(out, v.v+" "+strconv.Itoa(v.count))
+		}
+	}
+
+	return out, nil
+}
+
+// connections lists the two directions each pipe connects.
+var connections = map[rune][2]grid.Point{
+	'|': {grid.Up, grid.Down},
+	'-': {grid.Left, grid.Right},
+	'L': {grid.Up, grid.Right},
+	'J': {grid.Up, grid.Left},
+	'7': {grid.Down, grid.Left},
+	'F': {grid.Down, grid.Right},
+}
+
+// loop is the main loop of the maze.
+type loop struct {
+	// dist holds the steps along the loop from 'S' to every tile on it.
+	dist map[grid.Point]int
+	// start is the position of 'S' and startPipe the pipe hidden under it.
+	start     grid.Point
+	startPipe rune
+	farthest  int
+}
+
+// walk returns the tiles of the loop in order, starting from 'S'.
+func (l *loop) walk(maze *Maze) []grid.Point {
+	tiles := []grid.Point{l.start}
+	p, pipe, from := l.start, l.startPipe, grid.Point{}
+	for {
+		ends := connections[pipe]
+		d := ends[0]
+		if d == from {
+			d = ends[1]
+		}
+
+		if p = p.Add(d); p == l.start {
+			return tiles
+		}
+
+		tiles = append(tiles, p)
+		pipe, from = maze.At(p), d.Mul(-1)
+	}
+}
+
+// findLoop finds the pipe hidden under 'S' that closes a loop, walking the
+// loop breadth first from 'S' for every candidate. It stops with ctx.Err()
+// once ctx is done.
+func findLoop(ctx context.Context, maze *Maze) (*loop, error) {
+	s, ok := grid.Find(maze, 'S')
+	if !ok {
+		return nil, errors.New("pipes: no starting position 'S' in the maze")
+	}
+
+	var broken error
+	for _, pipe := range "-|JL7F" {
+		l := &loop{start: s, startPipe: pipe}
+		if len(l.neighbors(maze, s)) != 2 {
+			continue
+		}
+
+		res, err := graph.BFS[grid.Point](ctx, graph.Func[grid.Point](func(p grid.Point) []graph.Edge[grid.Point] {
+			return l.neighbors(maze, p)
+		}), s, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// every tile of a closed loop connects to exactly two others.
+		if p, ok := l.firstDeadEnd(maze, res.Dist); ok {
+			if broken == nil {
+				broken = fmt.Errorf("pipes: loop is broken at row %d, column %d", p.Y+1, p.X+1)
+			}
+
+			continue
+		}
+
+		l.dist = res.Dist
+		for _, d := range res.Dist {
+			l.farthest = max(l.farthest, d)
+		}
+
+		return l, nil
+	}
+
+	if broken == nil {
+		return nil, errors.New("pipes: no pipe connects to the starting position")
+	}
+
+	return nil, broken
+}
+
+// neighbors returns the edges to the tiles the pipe at p connects to and
+// that connect back to it, with 'S' standing for the start pipe.
+func (l *loop) neighbors(maze *Maze, p grid.Point) []graph.Edge[grid.Point] {
+	edges := make([]graph.Edge[grid.Point], 0, 2)
+	for _, d := range grid.Dirs4 {
+		q := p.Add(d)
+		if maze.In(q) && connects(l.pipe(maze, p), d) && connects(l.pipe(maze, q), d.Mul(-1)) {
+			edges = append(edges, graph.Edge[grid.Point]{To: q, Cost: 1})
+		}
+	}
+
+	return edges
+}
+
+// pipe returns the pipe at p, seeing through 'S'.
+func (l *loop) pipe(maze *Maze, p grid.Point) rune {
+	if p == l.start {
+		return l.startPipe
+	}
+
+	return maze.At(p)
+}
+
+// firstDeadEnd returns the first tile, row by row, among tiles that does not
+// connect to two others.
+func (l *loop) firstDeadEnd(maze *Maze, tiles map[grid.Point]int) (grid.Point, bool) {
+	var first grid.Point
+	found := false
+	for p := range tiles {
+		if len(l.neighbors(maze, p)) == 2 {
+			continue
+		}
+
+		if !found || p.Y < first.Y || p.Y == first.Y && p.X < first.X {
+			first, found = p, true
+		}
+	}
+
+	return first, found
+}
+
+// connects reports whether pipe has an opening towards dir.
+func connects(pipe rune, dir grid.Point) bool {
+	ends, ok := connections[pipe]
+	return ok && (ends[0] == dir || ends[1] == dir)
+}
+
+// findVisualArea calculates the visual representation and count for part 2.
+// It stops with ctx.Err() once ctx is done.
+func findVisualArea(ctx context.Context, l *loop, maze *Maze) (int, map[int]zoo, error) {
+	replaceWith := map[string]string{
+		"J": "┘", "L": "└", "7": "┐", "F": "┌", "|": "│", "-": "─",
+	}
+	mapPosition := make(map[int][]int)
+	resultMap := make(map[int]zoo)
+	sum := 0
+
+	for p := range l.dist {
+		mapPosition[p.Y] = append(mapPosition[p.Y], p.X)
+	}
+
+	row := 0
+	for k, v := range mapPosition {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+
+		aoc.ReportProgress(ctx, "rows", row, len(mapPosition))
+		row++
+
+		a := strings.Split(string(maze.Row(k)), "")
+		for _, j := range v {
+			if a[j] != "S" {
+				a[j] = replaceWith[a[j]]
+			} else {
+				a[j] = replaceWith[string(l.startPipe)]
+			}
+		}
+		// clean edges
+		left, right := false, false
+		for i, j := range a {
+			if i == (len(a) - 1 - i) {
+				break
+			}
+
+			r := a[len(a)-i-1]
+
+			if j == "┘" || j == "└" || j == "┐" || j == "┌" || j == "│" || j == "─" {
+				left = true
+			}
+
+			if r == "┘" || r == "└" || r == "┐" || r == "┌" || r == "│" || r == "─" {
+				right = true
+			}
+
+			if !left {
+				a[i] = " "
+			}
+
+			if !right {
+				a[len(a)-1-i] = " "
+			}
+		}
+		count := 0
+		isInside := 0
+		last := "-"
+
+		// traverse the cleaned input line and count the number of inside elements '|'
+		for i, char := range a {
+			if char == "│" {
+				isInside++
+				continue
+			}
+
+			if char == "─" {
+				continue
+			}
+
+			if last == "-" && (char == "┘" || char == "└" || char == "┐" || char == "┌") {
+				if char == "┘" || char == "└" || char == "┐" || char == "┌" {
+					last = char
+					continue
+				}
+			} else if last != "-" && (char == "┘" || char == "└" || char == "┐" || char == "┌") {
+				if last == "└" && char == "┐" {
+					isInside++
+				}
+
+				if last == "┌" && char == "┘" {
+					isInside++
+				}
+
+				last = "-"
+				continue
+			}
+
+			if isInside%2 == 0 {
+				a[i] = " "
+			}
+
+			if isInside%2 != 0 {
+				a[i] = "\033[0;32m█\033[0m"
+				count++
+			}
+		}
+		resultMap[k] = zoo{
+			v:     strings.Join(a, ""),
+			count: count,
+		}
+		sum += count
+	}
+
+	return sum, resultMap, nil
+}
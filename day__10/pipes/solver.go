@@ -0,0 +1,52 @@
+package pipes
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{
+		Day: 10, Title: "Pipe Maze", Dir: "day__10", New: New, Version: "1", Examples: examples, Generate: Generate,
+		Alternates: []aoc.Alternate{
+			{Name: "pick", Parts: []int{2}, New: func() aoc.Solver { return &pick{} }},
+		},
+	})
+}
+
+// New returns an aoc.Solver for day 10.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	maze *Maze
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.maze, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.maze)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.maze)
+}
+
+// pick counts the enclosed tiles with Part2Pick instead of casting rays.
+type pick struct {
+	solver
+}
+
+func (s *pick) PartTwo(ctx context.Context) (any, error) {
+	return Part2Pick(ctx, s.maze)
+}
@@ -0,0 +1,80 @@
+// Package galaxies solves day 11 of Advent of Code 2023, "Cosmic Expansion".
+package galaxies
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Image is the observatory image.
+type Image = grid.Grid[rune]
+
+// Parse reads the observatory image from r.
+func Parse(r io.Reader) (*Image, error) {
+	return grid.Parse(r, ".#")
+}
+
+// Part1 sums the shortest paths between every pair of galaxies when empty
+// rows and columns are twice as big. It stops with ctx.Err() once ctx is
+// done.
+func Part1(ctx context.Context, img *Image) (int, error) {
+	return SumDistances(ctx, img, 2)
+}
+
+// Part2 is Part1 with empty rows and columns one million times bigger.
+func Part2(ctx context.Context, img *Image) (int, error) {
+	return SumDistances(ctx, img, 1000000)
+}
+
+// SumDistances sums the shortest paths between every pair of galaxies after
+// each empty row and column grew expand times. It stops with ctx.Err() once
+// ctx is done.
+func SumDistances(ctx context.Context, img *Image, expand int) (totalDistance int, err error) {
+	disy := expansion(img.Height, img.Row, expand)
+	disx := expansion(img.Width, img.Col, expand)
+
+	galaxies := grid.FindAll(img, '#')
+	for i, g := range galaxies {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "galaxies", i, len(galaxies))
+
+		g = g.Add(grid.Point{X: disx[g.X], Y: disy[g.Y]})
+
+		for _, h := range galaxies[:i] {
+			h = h.Add(grid.Point{X: disx[h.X], Y: disy[h.Y]})
+			totalDistance += abs(g.X-h.X) + abs(g.Y-h.Y)
+		}
+	}
+
+	return totalDistance, nil
+}
+
+// expansion returns how far each of the n rows (or columns) moves once every
+// empty one up to it has grown expand times.
+func expansion(n int, line func(int) []rune, expand int) []int {
+	shift := make([]int, n)
+	for i, dis := 0, 0; i < n; i++ {
+		if !slices.Contains(line(i), '#') {
+			dis += expand - 1
+		}
+
+		shift[i] = dis
+	}
+
+	return shift
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,37 @@
+package galaxies
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes a random image, 140 by 140 pixels for a scale of 1, with
+// about one galaxy in 45 pixels and some rows and columns left empty.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	side := gen.Side(140, scale)
+
+	emptyCol := make([]bool, side)
+	for x := range emptyCol {
+		emptyCol[x] = rng.Intn(20) == 0
+	}
+
+	bw := bufio.NewWriter(w)
+	row := make([]byte, side)
+	for y := 0; y < side; y++ {
+		emptyRow := rng.Intn(20) == 0
+		for x := range row {
+			row[x] = '.'
+			if !emptyRow && !emptyCol[x] && rng.Intn(45) == 0 {
+				row[x] = '#'
+			}
+		}
+
+		bw.Write(row)
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
@@ -1,61 +1,54 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"image"
-	"math"
+	"io"
 	"os"
-	"strings"
-)
-
-func readLocalFile(filename string) ([]string, error) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
 
-	return strings.Fields(string(content)), nil
-}
+	"github.com/unkn0wn-root/advent_of_code_2023/day__11/galaxies"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
+)
 
 func main() {
-	fileContent, err := readLocalFile("input.txt")
-	if err != nil {
-		fmt.Println("Error reading file:", err)
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	flag.Parse()
+
+	if *format != output.Text {
+		if err := output.Day(os.Stdout, *format, 11, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
 		return
 	}
 
-	getDistances := func(expand int) (totalDistance int) {
-		galaxies := []image.Point{}
-		disy := 0
-
-		for y, row := range fileContent {
-			if !strings.Contains(row, "#") {
-				disy += expand - 1
-			}
-
-			disx := 0
-			for x, char := range row {
-				col := ""
-				for _, s := range fileContent {
-					col += string(s[x])
-				}
-
-				if !strings.Contains(col, "#") {
-					disx += expand - 1
-				}
-
-				if char == '#' {
-					for _, g := range galaxies {
-						totalDistance += int(math.Abs(float64(x+disx-g.X)) + math.Abs(float64(y+disy-g.Y)))
-					}
-					galaxies = append(galaxies, image.Point{x + disx, y + disy})
-				}
-			}
+	err := input.Each(*path, os.Stdout, func(r io.Reader) error {
+		img, err := galaxies.Parse(r)
+		if err != nil {
+			return err
 		}
 
-		return totalDistance
-	}
+		part1, err := galaxies.Part1(context.Background(), img)
+		if err != nil {
+			return err
+		}
+
+		part2, err := galaxies.Part2(context.Background(), img)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Part 1 (sum lengths):", part1)
+		fmt.Println("Part 2 (sum lengths):", part2)
 
-	fmt.Println("Part 1 (sum lengths):", getDistances(2))
-	fmt.Println("Part 2 (sum lengths):", getDistances(1000000))
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,31 @@
+package lava
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes a random floor, 110 by 110 tiles for a scale of 1, with a
+// mirror or splitter on about one tile in ten.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	side := gen.Side(110, scale)
+
+	bw := bufio.NewWriter(w)
+	row := make([]byte, side)
+	for y := 0; y < side; y++ {
+		for x := range row {
+			row[x] = '.'
+			if rng.Intn(10) == 0 {
+				row[x] = gen.Pick(rng, `|-/\`)
+			}
+		}
+
+		bw.Write(row)
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
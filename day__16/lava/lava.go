@@ -0,0 +1,175 @@
+// Package lava solves day 16 of Advent of Code 2023, "The Floor Will Be Lava".
+package lava
+
+import (
+	"context"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// directions a beam of light can travel in.
+var (
+	Right = grid.Right
+	Left  = grid.Left
+	Up    = grid.Up
+	Down  = grid.Down
+)
+
+var (
+	reflectors = map[rune]map[grid.Point]grid.Point{
+		'/':  {Up: Right, Right: Up, Down: Left, Left: Down},
+		'\\': {Up: Left, Right: Down, Left: Up, Down: Right},
+	}
+
+	forks = map[rune][]grid.Point{
+		'-': {Right, Left},
+		'|': {Up, Down},
+	}
+)
+
+// Particle is the head of a beam of light.
+type Particle struct {
+	Position  grid.Point
+	Direction grid.Point
+}
+
+// checkEvery is how many beam moves are simulated between two looks at
+// whether the context is done.
+const checkEvery = 1024
+
+// Floor is the parsed contraption.
+type Floor = grid.Grid[rune]
+
+// Parse reads the contraption layout from r.
+func Parse(r io.Reader) (*Floor, error) {
+	return grid.Parse(r, `.|-/\`)
+}
+
+// Energize sends a beam from particle through the floor and returns the
+// number of tiles it energizes.
+func Energize(f *Floor, particle Particle) int {
+	energized, _ := traverseFloor(context.Background(), f, particle)
+	return energized
+}
+
+// Part1 returns the number of tiles energized by a beam entering the top-left
+// corner heading right. It stops with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, f *Floor) (int, error) {
+	return partOne(ctx, f)
+}
+
+// Part2 returns the largest number of tiles any beam entering from an edge
+// energizes. It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, f *Floor) (int, error) {
+	return partTwo(ctx, f)
+}
+
+// simulate the movement of particles on the floor and returns the number of visited positions.
+// It stops with ctx.Err() once ctx is done.
+func traverseFloor(ctx context.Context, floor *Floor, particle Particle) (int, error) {
+	// the directions each position has been visited in, one bit per direction.
+	visited := grid.New[uint8](floor.Width, floor.Height)
+	energized := 0
+	particles := []Particle{particle}
+
+	for moved := 0; len(particles) > 0; moved++ {
+		if moved%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		particle, particles = particles[0], particles[1:]
+
+		// check if the particle is out of bounds.
+		if !floor.In(particle.Position) {
+			continue
+		}
+
+		// check if the particle has visited the current position in the same direction before.
+		seen := visited.At(particle.Position)
+		if seen&directionBit(particle.Direction) != 0 {
+			continue
+		}
+
+		// mark the current position as visited in the specified direction.
+		if seen == 0 {
+			energized++
+		}
+		visited.Set(particle.Position, seen|directionBit(particle.Direction))
+
+		tile := floor.At(particle.Position)
+
+		// check if there is a reflector at the current position.
+		if v, ok := reflectors[tile][particle.Direction]; ok {
+			particle.Direction = v
+		}
+
+		// check if there is a fork at the current position.
+		if fork, ok := forks[tile]; ok {
+			// create a new particle with the second direction of the fork.
+			particles = append(particles, Particle{Position: particle.Position.Add(fork[1]), Direction: fork[1]})
+			// change the direction of the current particle to the first direction of the fork.
+			particle.Direction = fork[0]
+		}
+
+		// move the particle to the next position.
+		particle.Position = particle.Position.Add(particle.Direction)
+		particles = append(particles, particle)
+	}
+
+	return energized, nil
+}
+
+// simulate the movement of a particle starting from the top-left corner and returns the number of visited positions.
+func partOne(ctx context.Context, floor *Floor) (int, error) {
+	return traverseFloor(ctx, floor, Particle{Position: grid.Point{X: 0, Y: 0}, Direction: Right})
+}
+
+// simulate the movement of particles from different starting positions and returns the maximum number of visited positions.
+func partTwo(ctx context.Context, floor *Floor) (int, error) {
+	var starts []Particle
+	for x := 0; x < floor.Width; x++ {
+		starts = append(starts,
+			Particle{Position: grid.Point{X: x, Y: 0}, Direction: Down},
+			Particle{Position: grid.Point{X: x, Y: floor.Height - 1}, Direction: Up},
+		)
+	}
+
+	for y := 0; y < floor.Height; y++ {
+		starts = append(starts,
+			Particle{Position: grid.Point{X: 0, Y: y}, Direction: Right},
+			Particle{Position: grid.Point{X: floor.Width - 1, Y: y}, Direction: Left},
+		)
+	}
+
+	maxCoverage := 0
+	for i, start := range starts {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "entry points", i, len(starts))
+		energized, err := traverseFloor(ctx, floor, start)
+		if err != nil {
+			return 0, err
+		}
+
+		maxCoverage = max(maxCoverage, energized)
+	}
+
+	return maxCoverage, nil
+}
+
+// directionBit returns the bit standing for dir in a visited mask.
+func directionBit(dir grid.Point) uint8 {
+	for i, d := range grid.Dirs4 {
+		if d == dir {
+			return 1 << i
+		}
+	}
+
+	return 0
+}
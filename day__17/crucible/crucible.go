@@ -0,0 +1,111 @@
+// Package crucible solves day 17 of Advent of Code 2023, "Clumsy Crucible".
+package crucible
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/graph"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Direction is the axis a crucible moves along. Undecided is the axis of the
+// start, which it may leave either way.
+type Direction int
+
+const (
+	Vertical Direction = iota
+	Horizontal
+	Undecided
+)
+
+// Node is a state of the search: the block a crucible is on and the axis it
+// arrived along, which it has to turn away from.
+type Node struct {
+	grid.Point
+	Direction Direction
+}
+
+// Parse reads the map of heat loss per city block from r.
+func Parse(r io.Reader) (*grid.Grid[int], error) {
+	digits, err := grid.Parse(r, "0123456789")
+	if err != nil {
+		return nil, err
+	}
+
+	return grid.Map(digits, func(ch rune) int { return int(ch - '0') }), nil
+}
+
+// Part1 returns the least heat loss for a crucible that moves at most three
+// blocks in a single direction.
+func Part1(ctx context.Context, heatLoss *grid.Grid[int]) (int, error) {
+	return ShortestPath(ctx, heatLoss, 1, 3)
+}
+
+// Part2 returns the least heat loss for an ultra crucible that moves between
+// four and ten blocks before turning.
+func Part2(ctx context.Context, heatLoss *grid.Grid[int]) (int, error) {
+	return ShortestPath(ctx, heatLoss, 4, 10)
+}
+
+// return the nodes reachable from node by turning and moving between
+// minSteps and maxSteps blocks, with the heat lost on the way.
+func getNeighbors(heatLoss *grid.Grid[int], node Node, minSteps, maxSteps int) []graph.Edge[Node] {
+	neighbors := make([]graph.Edge[Node], 0, 2*(maxSteps-minSteps+1))
+
+	var dirs []grid.Point
+	if node.Direction == Horizontal || node.Direction == Undecided {
+		dirs = append(dirs, grid.Down, grid.Up)
+	}
+
+	if node.Direction == Vertical || node.Direction == Undecided {
+		dirs = append(dirs, grid.Right, grid.Left)
+	}
+
+	for _, d := range dirs {
+		axis := Vertical
+		if d.Y == 0 {
+			axis = Horizontal
+		}
+
+		p, loss := node.Point, 0
+		for step := 1; step <= maxSteps; step++ {
+			p = p.Add(d)
+			if !heatLoss.In(p) {
+				break
+			}
+
+			loss += heatLoss.At(p)
+			if step >= minSteps {
+				neighbors = append(neighbors, graph.Edge[Node]{To: Node{Point: p, Direction: axis}, Cost: loss})
+			}
+		}
+	}
+
+	return neighbors
+}
+
+// ShortestPath uses Dijkstra's algorithm to find the least heat loss from the
+// top-left to the bottom-right block, moving between minSteps and maxSteps
+// blocks before each turn. It stops with ctx.Err() once ctx is done.
+func ShortestPath(ctx context.Context, heatLoss *grid.Grid[int], minSteps, maxSteps int) (int, error) {
+	end := grid.Point{X: heatLoss.Width - 1, Y: heatLoss.Height - 1}
+
+	neighbors := graph.Func[Node](func(n Node) []graph.Edge[Node] {
+		return getNeighbors(heatLoss, n, minSteps, maxSteps)
+	})
+
+	res, err := graph.Dijkstra[Node](ctx, neighbors, Node{Direction: Undecided}, func(n Node) bool {
+		return n.Point == end
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if !res.Found {
+		return 0, errors.New("crucible: the bottom-right block cannot be reached")
+	}
+
+	return res.Dist[res.Goal], nil
+}
@@ -0,0 +1,28 @@
+package crucible
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes a random map of heat loss, 141 by 141 blocks for a scale
+// of 1, of digits from 1 to 9.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	side := gen.Side(141, scale)
+
+	bw := bufio.NewWriter(w)
+	row := make([]byte, side)
+	for y := 0; y < side; y++ {
+		for x := range row {
+			row[x] = byte(gen.Between(rng, '1', '9'))
+		}
+
+		bw.Write(row)
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
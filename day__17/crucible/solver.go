@@ -0,0 +1,39 @@
+package crucible
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{Day: 17, Title: "Clumsy Crucible", Dir: "day__17", New: New, Version: "1", Examples: examples, Generate: Generate})
+}
+
+// New returns an aoc.Solver for day 17.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	heatLoss *grid.Grid[int]
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.heatLoss, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.heatLoss)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.heatLoss)
+}
@@ -0,0 +1,95 @@
+package lagoon
+
+import (
+	"context"
+	"image"
+	"sort"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// FloodArea is Area found by flood filling the ground around the trench
+// instead of with the shoelace formula. The ground is first cut into cells
+// at every corner of the trench, so that a huge lagoon takes no more cells
+// than a small one with the same turns. It stops with ctx.Err() once ctx is
+// done.
+func FloodArea(ctx context.Context, moves []Move) (int, error) {
+	corners := []image.Point{{}}
+	for _, m := range moves {
+		corners = append(corners, corners[len(corners)-1].Add(m.Delta.Mul(m.Length)))
+	}
+
+	xs := cuts(corners, func(p image.Point) int { return p.X })
+	ys := cuts(corners, func(p image.Point) int { return p.Y })
+
+	// cell (i, j) is the ground from xs[i] to xs[i+1] and ys[j] to ys[j+1],
+	// wholly trench or wholly not.
+	trench := grid.New[bool](len(xs)-1, len(ys)-1)
+	for i := 1; i < len(corners); i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "trench corners", i, len(corners))
+
+		a, b := corners[i-1], corners[i]
+		for x := sort.SearchInts(xs, min(a.X, b.X)); xs[x] <= max(a.X, b.X); x++ {
+			for y := sort.SearchInts(ys, min(a.Y, b.Y)); ys[y] <= max(a.Y, b.Y); y++ {
+				trench.Set(grid.Point{X: x, Y: y}, true)
+			}
+		}
+	}
+
+	// the cuts leave a margin of ground around the trench, so the first
+	// cell is outside the lagoon and every outside cell can be reached from it.
+	outside := grid.New[bool](trench.Width, trench.Height)
+	outside.Set(grid.Point{}, true)
+	for stack, filled := []grid.Point{{}}, 0; len(stack) > 0; filled++ {
+		if filled%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+
+			aoc.ReportProgress(ctx, "cells filled", filled, outside.Width*outside.Height)
+		}
+
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, q := range trench.Neighbors4(p) {
+			if !trench.At(q) && !outside.At(q) {
+				outside.Set(q, true)
+				stack = append(stack, q)
+			}
+		}
+	}
+
+	area := 0
+	outside.Each(func(p grid.Point, out bool) {
+		if !out {
+			area += (xs[p.X+1] - xs[p.X]) * (ys[p.Y+1] - ys[p.Y])
+		}
+	})
+
+	return area, nil
+}
+
+// cuts returns, in order, the coordinates that start and end the trench
+// rows or columns through corners, plus a margin on both sides.
+func cuts(corners []image.Point, coord func(image.Point) int) []int {
+	seen := make(map[int]bool)
+	for _, c := range corners {
+		seen[coord(c)] = true
+		seen[coord(c)+1] = true
+	}
+
+	out := make([]int, 0, len(seen)+2)
+	for v := range seen {
+		out = append(out, v)
+	}
+
+	sort.Ints(out)
+
+	return append(append([]int{out[0] - 1}, out...), out[len(out)-1]+1)
+}
@@ -0,0 +1,75 @@
+package lagoon
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Generate writes a random dig plan of about scale times 700 steps. The
+// plan and the plan hidden in its colors both dig a simple closed loop: the
+// outline of the same random shape, stretched differently.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	// the shape is drawn on a coarse grid whose columns and rows are then
+	// given random widths, once for each plan.
+	side := gen.Side(70, scale)
+	outline := gen.Outline(gen.Blob(rng, side, side, 0.5))
+
+	// widths of at least 2 keep a gap between neighbouring sides, so the
+	// trench never touches itself. The colors hold five hex digits, so even
+	// a step across the whole grid must stay below 1<<20.
+	xs, ys := offsets(rng, side, 2, 10), offsets(rng, side, 2, 10)
+	hxs, hys := offsets(rng, side, 2, (1<<20-1)/side), offsets(rng, side, 2, (1<<20-1)/side)
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < len(outline); {
+		// outline starts at a corner: follow the side to the next one.
+		from, d := outline[i], outline[(i+1)%len(outline)].Sub(outline[i])
+		for i++; outline[i%len(outline)].Add(d) == outline[(i+1)%len(outline)]; i++ {
+		}
+
+		to := outline[i%len(outline)]
+		length := abs(xs[to.X]-xs[from.X]) + abs(ys[to.Y]-ys[from.Y])
+		hidden := abs(hxs[to.X]-hxs[from.X]) + abs(hys[to.Y]-hys[from.Y])
+		fmt.Fprintf(bw, "%c %d (#%05x%c)\n", "RDLU"[dirIndex(d)], length, hidden, "0123"[dirIndex(d)])
+	}
+
+	return bw.Flush()
+}
+
+// offsets returns the positions of the n+1 lines between n columns or rows
+// of random widths in [lo, hi].
+func offsets(rng *rand.Rand, n, lo, hi int) []int {
+	out := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		out[i] = out[i-1] + gen.Between(rng, lo, hi)
+	}
+
+	return out
+}
+
+// dirIndex returns the position of d in the order R, D, L, U of the plan.
+func dirIndex(d grid.Point) int {
+	switch d {
+	case grid.Right:
+		return 0
+	case grid.Down:
+		return 1
+	case grid.Left:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
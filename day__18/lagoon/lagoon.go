@@ -0,0 +1,204 @@
+// Package lagoon solves day 18 of Advent of Code 2023, "Lavaduct Lagoon".
+package lagoon
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// represents a change in coordinates based on a direction or digit.
+type delta map[string]image.Point
+
+// Step is one line of the dig plan.
+type Step struct {
+	Direction string
+	Length    int
+	// Color is the hexadecimal color code without the leading '#'.
+	Color string
+}
+
+// Move is a straight trench dug Length meters in the direction of Delta.
+type Move struct {
+	Delta  image.Point
+	Length int
+}
+
+var (
+	hexColor = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+	// maps plan directions and color digits to coordinate changes.
+	directions = delta{
+		"R": {1, 0}, "D": {0, 1}, "L": {-1, 0}, "U": {0, -1},
+		"0": {1, 0}, "1": {0, 1}, "2": {-1, 0}, "3": {0, -1},
+	}
+)
+
+// Parse reads the dig plan from r. A plan that does not lead back to where
+// it starts digs no lagoon and is an error.
+func Parse(r io.Reader) ([]Step, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]Step, 0, len(lines))
+	for i, line := range lines {
+		step, err := parseStep(parse.NewCursor(i+1, line))
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	if p := end(planMoves(steps)); p != (image.Point{}) {
+		return nil, &parse.Error{Line: len(lines), Expected: "a plan that returns to its start", Found: fmt.Sprintf("end at %d,%d", p.X, p.Y)}
+	}
+
+	return steps, nil
+}
+
+// parses a line of the form "R 6 (#70c710)".
+func parseStep(c *parse.Cursor) (Step, error) {
+	column := c.Column()
+	direction, err := c.Until(" ")
+	if err != nil {
+		return Step{}, err
+	}
+
+	if len(direction) != 1 || !strings.Contains("RDLU", direction) {
+		return Step{}, &parse.Error{Line: c.Line(), Column: column, Expected: "direction R, D, L or U", Found: direction}
+	}
+
+	if err := c.Expect(" "); err != nil {
+		return Step{}, err
+	}
+
+	length, err := c.Int()
+	if err != nil {
+		return Step{}, err
+	}
+
+	if err := c.Expect(" (#"); err != nil {
+		return Step{}, err
+	}
+
+	column = c.Column()
+	color, err := c.Until(")")
+	if err != nil {
+		return Step{}, err
+	}
+
+	if !hexColor.MatchString(color) {
+		return Step{}, &parse.Error{Line: c.Line(), Column: column, Expected: "six hexadecimal digits", Found: color}
+	}
+
+	if err := c.Expect(")"); err != nil {
+		return Step{}, err
+	}
+
+	return Step{Direction: direction, Length: length, Color: color}, c.End()
+}
+
+// checkEvery is how many moves or cells are processed between two looks at
+// whether the context is done.
+const checkEvery = 1024
+
+// Part1 returns the lagoon volume when following the directions and lengths of the plan.
+// It stops with ctx.Err() once ctx is done.
+func Part1(ctx context.Context, steps []Step) (int, error) {
+	return Area(ctx, planMoves(steps))
+}
+
+// Part2 returns the lagoon volume when the instructions are hidden in the color codes:
+// five hex digits of length followed by one digit of direction. It stops with
+// ctx.Err() once ctx is done.
+func Part2(ctx context.Context, steps []Step) (int, error) {
+	moves, err := colorMoves(steps)
+	if err != nil {
+		return 0, err
+	}
+
+	return Area(ctx, moves)
+}
+
+// returns the moves given by the directions and lengths of the plan.
+func planMoves(steps []Step) []Move {
+	moves := make([]Move, len(steps))
+	for i, s := range steps {
+		moves[i] = Move{Delta: directions[s.Direction], Length: s.Length}
+	}
+
+	return moves
+}
+
+// returns the moves hidden in the color codes of the plan, which have to
+// lead back to where they start too.
+func colorMoves(steps []Step) ([]Move, error) {
+	moves := make([]Move, len(steps))
+	for i, s := range steps {
+		length, _ := strconv.ParseInt(s.Color[:5], 16, strconv.IntSize)
+		d, ok := directions[s.Color[5:]]
+		if !ok {
+			return nil, fmt.Errorf("lagoon: step %d: color #%s encodes unknown direction %q", i+1, s.Color, s.Color[5:])
+		}
+
+		moves[i] = Move{Delta: d, Length: int(length)}
+	}
+
+	if p := end(moves); p != (image.Point{}) {
+		return nil, fmt.Errorf("lagoon: the plan hidden in the colors ends at %d,%d instead of its start", p.X, p.Y)
+	}
+
+	return moves, nil
+}
+
+// returns where moves lead from the origin.
+func end(moves []Move) image.Point {
+	var p image.Point
+	for _, m := range moves {
+		p = p.Add(m.Delta.Mul(m.Length))
+	}
+
+	return p
+}
+
+// Area returns the number of cubic meters the lagoon dug by moves holds,
+// counting both the trench and its interior. It stops with ctx.Err() once
+// ctx is done.
+func Area(ctx context.Context, moves []Move) (int, error) {
+	currentPosition, shoelace, perimeter := image.Point{0, 0}, 0, 0
+	for i, m := range moves {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+
+			aoc.ReportProgress(ctx, "moves", i, len(moves))
+		}
+
+		newPosition := currentPosition.Add(m.Delta.Mul(m.Length))
+
+		// calculate the area using the Shoelace formula
+		shoelace += currentPosition.X*newPosition.Y - currentPosition.Y*newPosition.X
+		perimeter += m.Length
+
+		currentPosition = newPosition
+	}
+
+	// the sign of the shoelace sum only tells whether the plan runs clockwise.
+	if shoelace < 0 {
+		shoelace = -shoelace
+	}
+
+	return (shoelace+perimeter)/2 + 1, nil
+}
@@ -0,0 +1,104 @@
+package lagoon_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/day__18/lagoon"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+const example = `R 6 (#70c710)
+D 5 (#0dc571)
+L 2 (#5713f0)
+D 2 (#d2c081)
+R 2 (#59c680)
+D 2 (#411b91)
+L 5 (#8ceee2)
+U 2 (#caa173)
+L 1 (#1b58a2)
+U 2 (#caa171)
+R 2 (#7807d2)
+U 3 (#a77fa3)
+L 2 (#015232)
+U 2 (#7a21e3)`
+
+func TestParseRejectsOpenPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		plan string
+	}{
+		{"stops short", "R 6 (#70c710)\nD 5 (#0dc571)\nL 6 (#5713f0)\nU 4 (#d2c081)"},
+		{"one step", "R 6 (#70c710)"},
+		{"overshoots", strings.Replace(example, "U 2 (#7a21e3)", "U 3 (#7a21e3)", 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lagoon.Parse(strings.NewReader(tt.plan))
+
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse error = %v, want a *parse.Error", err)
+			}
+
+			if last := strings.Count(tt.plan, "\n") + 1; perr.Line != last {
+				t.Errorf("Parse error at line %d, want the last line %d: %v", perr.Line, last, err)
+			}
+		})
+	}
+}
+
+func TestPart2RejectsOpenColorPlans(t *testing.T) {
+	// the plan still closes, but its last color now digs one meter too far up.
+	steps, err := lagoon.Parse(strings.NewReader(strings.Replace(example, "#7a21e3", "#7a21f3", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := lagoon.Part2(context.Background(), steps); err == nil {
+		t.Errorf("Part2 = %d, want an error", got)
+	}
+}
+
+func TestParts(t *testing.T) {
+	steps, err := lagoon.Parse(strings.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := lagoon.Part1(context.Background(), steps); err != nil || got != 62 {
+		t.Errorf("Part1 = %d, %v, want 62", got, err)
+	}
+
+	if got, err := lagoon.Part2(context.Background(), steps); err != nil || got != 952408144115 {
+		t.Errorf("Part2 = %d, %v, want 952408144115", got, err)
+	}
+}
+
+func TestAreaMatchesFloodArea(t *testing.T) {
+	// a U whose notch is one meter wide.
+	moves := []lagoon.Move{
+		{Delta: image.Point{1, 0}, Length: 4},
+		{Delta: image.Point{0, 1}, Length: 4},
+		{Delta: image.Point{-1, 0}, Length: 1},
+		{Delta: image.Point{0, -1}, Length: 2},
+		{Delta: image.Point{-1, 0}, Length: 2},
+		{Delta: image.Point{0, 1}, Length: 2},
+		{Delta: image.Point{-1, 0}, Length: 1},
+		{Delta: image.Point{0, -1}, Length: 4},
+	}
+
+	area, err := lagoon.Area(context.Background(), moves)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flood, err := lagoon.FloodArea(context.Background(), moves)
+	if err != nil || flood != area || area != 25-2 {
+		t.Errorf("Area = %d, FloodArea = %d, %v, want both 23", area, flood, err)
+	}
+}
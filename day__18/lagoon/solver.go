@@ -0,0 +1,61 @@
+package lagoon
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{
+		Day: 18, Title: "Lavaduct Lagoon", Dir: "day__18", New: New, Version: "1", Examples: examples, Generate: Generate,
+		Alternates: []aoc.Alternate{
+			{Name: "flood-fill", Parts: []int{1, 2}, New: func() aoc.Solver { return &floodFill{} }},
+		},
+	})
+}
+
+// New returns an aoc.Solver for day 18.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	steps []Step
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.steps, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.steps)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.steps)
+}
+
+// floodFill measures the lagoon with FloodArea instead of Area.
+type floodFill struct {
+	solver
+}
+
+func (s *floodFill) PartOne(ctx context.Context) (any, error) {
+	return FloodArea(ctx, planMoves(s.steps))
+}
+
+func (s *floodFill) PartTwo(ctx context.Context) (any, error) {
+	moves, err := colorMoves(s.steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return FloodArea(ctx, moves)
+}
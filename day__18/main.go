@@ -1,63 +1,54 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"image"
-	"log"
+	"io"
 	"os"
-	"regexp"
-	"strconv"
-)
-
-// represents a change in coordinates based on a direction or digit.
-type delta map[string]image.Point
 
-func readInputFile(filename string) (string, error) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return "", err
-	}
+	"github.com/unkn0wn-root/advent_of_code_2023/day__18/lagoon"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/output"
+)
 
-	return string(content), nil
-}
+func main() {
+	path := flag.String("input", input.DefaultFile, "puzzle input: a file, - for stdin, or a directory of *.txt files")
+	format := flag.String("format", output.Text, "output format: text, json or tsv")
+	flag.Parse()
 
-func compileRegex() *regexp.Regexp {
-	return regexp.MustCompile(`(.) (.*) \(#(.*)(.)\)`)
-}
+	if *format != output.Text {
+		if err := output.Day(os.Stdout, *format, 18, *path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 
-func initializeDelta() delta {
-	// initializes and returns the Delta map for direction mappings.
-	return delta{
-		"R": {1, 0}, "D": {0, 1}, "L": {-1, 0}, "U": {0, -1},
-		"0": {1, 0}, "1": {0, 1}, "2": {-1, 0}, "3": {0, -1},
+		return
 	}
-}
 
-// calculates the area based on the parsed input, delta, and parameters.
-func calculateArea(input string, regex *regexp.Regexp, delta delta, directionIdx, lengthIdx, base int) int {
-	currentPosition, totalArea := image.Point{0, 0}, 0
-	for _, match := range regex.FindAllStringSubmatch(input, -1) {
-		length, _ := strconv.ParseInt(match[lengthIdx], base, strconv.IntSize)
-		newPosition := currentPosition.Add(delta[match[directionIdx]].Mul(int(length)))
+	err := input.Each(*path, os.Stdout, func(r io.Reader) error {
+		steps, err := lagoon.Parse(r)
+		if err != nil {
+			return err
+		}
 
-		// calculate the area using the Shoelace formula
-		totalArea += currentPosition.X*newPosition.Y - currentPosition.Y*newPosition.X + int(length)
+		part1, err := lagoon.Part1(context.Background(), steps)
+		if err != nil {
+			return err
+		}
 
-		currentPosition = newPosition
-	}
+		part2, err := lagoon.Part2(context.Background(), steps)
+		if err != nil {
+			return err
+		}
 
-	return totalArea/2 + 1
-}
+		fmt.Println("Part 1:", part1)
+		fmt.Println("Part 2:", part2)
 
-func main() {
-	input, err := readInputFile("input.txt")
+		return nil
+	})
 	if err != nil {
-		log.Fatal("Could not read the file. Error: ", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	regex := compileRegex()
-	delta := initializeDelta()
-
-	fmt.Println("Part 1:", calculateArea(input, regex, delta, 1, 2, 10))
-	fmt.Println("Part 2:", calculateArea(input, regex, delta, 4, 3, 16))
 }
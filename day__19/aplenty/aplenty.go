@@ -0,0 +1,372 @@
+// Package aplenty solves day 19 of Advent of Code 2023, "Aplenty".
+package aplenty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/interval"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Part is a machine part: its rating in each category x, m, a and s.
+type Part map[rune]int
+
+// Rule is one step of a workflow: a part whose rating in Category compares
+// to Right by Operator goes to Consequence, a workflow name or A or R. A rule
+// without an operator always applies.
+type Rule struct {
+	Category, Operator rune
+	Right              int
+	Consequence        string
+}
+
+// Workflow is the list of rules a part is checked against, in order.
+type Workflow []Rule
+
+// System is the parsed puzzle input: the workflows by name and the parts to sort.
+type System struct {
+	Workflows map[string]Workflow
+	Parts     []Part
+}
+
+// Parse reads the workflows and the list of parts from r.
+func Parse(r io.Reader) (*System, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows, parts, err := parseInput(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return &System{Workflows: workflows, Parts: parts}, nil
+}
+
+// Part1 sums the ratings of every accepted part. It stops with ctx.Err()
+// once ctx is done.
+func Part1(ctx context.Context, s *System) (int, error) {
+	return pt1(ctx, s.Workflows, s.Parts)
+}
+
+// Part2 counts the distinct combinations of ratings from 1 to 4000 that are
+// accepted. It stops with ctx.Err() once ctx is done.
+func Part2(ctx context.Context, s *System) (int, error) {
+	ratings := interval.Of(1, 4000)
+	return count(ctx, s.Workflows, "in", interval.Box{ratings, ratings, ratings, ratings})
+}
+
+// Part1Split is Part1 by range splitting: it finds every box of ratings the
+// workflows accept, as Part2 does, and sums the parts that lie in one.
+func Part1Split(ctx context.Context, s *System) (int, error) {
+	ratings := interval.Of(1, 4000)
+
+	var boxes []interval.Box
+	err := accept(ctx, s.Workflows, "in", interval.Box{ratings, ratings, ratings, ratings}, func(b interval.Box) {
+		boxes = append(boxes, b)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0
+	for i, p := range s.Parts {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "parts", i, len(s.Parts))
+
+		point := []int{p['x'], p['m'], p['a'], p['s']}
+		for _, b := range boxes {
+			if b.Contains(point) {
+				sum += sumPartValues(p)
+				break
+			}
+		}
+	}
+
+	return sum, nil
+}
+
+// Accepted reports whether the workflows accept part p.
+func Accepted(s *System, p Part) bool {
+	return applyWorkflow(s.Workflows, "in", p)
+}
+
+// parses a workflow line of the form "px{a<2006:qkq,m>2090:A,rfg}".
+func parseWorkflow(c *parse.Cursor) (string, Workflow, error) {
+	name, err := c.Word()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := c.Expect("{"); err != nil {
+		return "", nil, err
+	}
+
+	w := make(Workflow, 0, 4)
+	for {
+		r, err := parseRule(c)
+		if err != nil {
+			return "", nil, err
+		}
+
+		w = append(w, r)
+
+		if c.Accept("}") {
+			break
+		}
+
+		if err := c.Expect(","); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return name, w, c.End()
+}
+
+// parses a rule such as "a<2006:qkq", or a bare consequence like "rfg".
+func parseRule(c *parse.Cursor) (Rule, error) {
+	var r Rule
+
+	column := c.Column()
+	word, err := c.Word()
+	if err != nil {
+		return r, err
+	}
+
+	if !strings.HasPrefix(c.Rest(), "<") && !strings.HasPrefix(c.Rest(), ">") {
+		r.Consequence = word
+		return r, nil
+	}
+
+	if len(word) != 1 || !strings.Contains("xmas", word) {
+		return r, &parse.Error{Line: c.Line(), Column: column, Expected: "category x, m, a or s", Found: word}
+	}
+
+	r.Category, r.Operator = rune(word[0]), rune(c.Rest()[0])
+	c.Accept(c.Rest()[:1])
+
+	if r.Right, err = c.Int(); err != nil {
+		return r, err
+	}
+
+	if err := c.Expect(":"); err != nil {
+		return r, err
+	}
+
+	if r.Consequence, err = c.Word(); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// parses a part of the form "{x=787,m=2655,a=1222,s=2876}".
+func parsePart(c *parse.Cursor) (Part, error) {
+	part := Part{}
+	if err := c.Expect("{"); err != nil {
+		return nil, err
+	}
+
+	for i, category := range "xmas" {
+		if i > 0 {
+			if err := c.Expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := c.Expect(string(category) + "="); err != nil {
+			return nil, err
+		}
+
+		v, err := c.Int()
+		if err != nil {
+			return nil, err
+		}
+
+		part[category] = v
+	}
+
+	if err := c.Expect("}"); err != nil {
+		return nil, err
+	}
+
+	return part, c.End()
+}
+
+// parses the input lines and returns workflows and parts.
+func parseInput(lines []string) (map[string]Workflow, []Part, error) {
+	blank := -1
+	for i, line := range lines {
+		if line == "" {
+			blank = i
+			break
+		}
+	}
+
+	if blank < 0 {
+		return nil, nil, &parse.Error{Line: len(lines) + 1, Expected: "blank line between workflows and parts"}
+	}
+
+	// parse workflows
+	workflows := make(map[string]Workflow)
+	defined := make(map[string]int)
+	for i, line := range lines[:blank] {
+		name, w, err := parseWorkflow(parse.NewCursor(i+1, line))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if first, ok := defined[name]; ok {
+			return nil, nil, &parse.Error{Line: i + 1, Column: 1, Expected: fmt.Sprintf("new workflow name (%s is defined on line %d)", name, first), Found: name}
+		}
+
+		workflows[name] = w
+		defined[name] = i + 1
+	}
+
+	if _, ok := workflows["in"]; !ok {
+		return nil, nil, errors.New("aplenty: no \"in\" workflow")
+	}
+
+	// every consequence has to lead somewhere
+	for name, w := range workflows {
+		for _, r := range w {
+			if _, ok := workflows[r.Consequence]; !ok && r.Consequence != "A" && r.Consequence != "R" {
+				return nil, nil, &parse.Error{Line: defined[name], Expected: "A, R or a defined workflow", Found: r.Consequence}
+			}
+		}
+	}
+
+	// parse parts
+	parts := make([]Part, 0)
+	for i, line := range lines[blank+1:] {
+		part, err := parsePart(parse.NewCursor(blank+i+2, line))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parts = append(parts, part)
+	}
+
+	return workflows, parts, nil
+}
+
+// applies the given workflow on a part and returns the result.
+func applyWorkflow(workflows map[string]Workflow, workflow string, part Part) bool {
+	if workflow == "R" {
+		return false
+	} else if workflow == "A" {
+		return true
+	}
+
+	for _, r := range workflows[workflow] {
+		if evaluateRule(r, part) {
+			return applyWorkflow(workflows, r.Consequence, part)
+		}
+	}
+
+	return false
+}
+
+// evaluates a rule against a part and returns the result.
+func evaluateRule(r Rule, part Part) bool {
+	switch r.Operator {
+	case '>':
+		return part[r.Category] > r.Right
+	case '<':
+		return part[r.Category] < r.Right
+	default:
+		return true
+	}
+}
+
+func pt1(ctx context.Context, workflows map[string]Workflow, parts []Part) (int, error) {
+	sum := 0
+	for i, p := range parts {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		aoc.ReportProgress(ctx, "parts", i, len(parts))
+
+		if applyWorkflow(workflows, "in", p) {
+			sum += sumPartValues(p)
+		}
+	}
+
+	return sum, nil
+}
+
+// returns the sum of values in a part.
+func sumPartValues(p Part) int {
+	sum := 0
+	for _, v := range p {
+		sum += v
+	}
+
+	return sum
+}
+
+// categories are the dimensions of a box of parts, in order.
+const categories = "xmas"
+
+// counts the parts of the box that end up accepted.
+func count(ctx context.Context, workflows map[string]Workflow, workflow string, parts interval.Box) (int, error) {
+	total := 0
+	err := accept(ctx, workflows, workflow, parts, func(b interval.Box) {
+		total += b.Volume()
+	})
+
+	return total, err
+}
+
+// splits the box at every rule and calls fn with each piece that ends up
+// accepted. It stops with ctx.Err() once ctx is done.
+func accept(ctx context.Context, workflows map[string]Workflow, workflow string, parts interval.Box, fn func(interval.Box)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if workflow == "R" || parts.Empty() {
+		return nil
+	} else if workflow == "A" {
+		fn(parts)
+		return nil
+	}
+
+	for _, r := range workflows[workflow] {
+		if r.Operator == 0 {
+			return accept(ctx, workflows, r.Consequence, parts, fn)
+		}
+
+		var pass, fail interval.Box
+		dim := strings.IndexRune(categories, r.Category)
+		if r.Operator == '<' {
+			pass, fail = parts.SplitAt(dim, r.Right)
+		} else {
+			fail, pass = parts.SplitAt(dim, r.Right+1)
+		}
+
+		if err := accept(ctx, workflows, r.Consequence, pass, fn); err != nil {
+			return err
+		}
+
+		if fail.Empty() {
+			return nil
+		}
+
+		parts = fail
+	}
+
+	return nil
+}
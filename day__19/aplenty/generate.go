@@ -0,0 +1,78 @@
+package aplenty
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/gen"
+)
+
+// Generate writes scale times 550 random workflows and scale times 200
+// parts. Like in the real inputs the workflows form a tree rooted at "in",
+// so every part ends up accepted or rejected.
+func Generate(w io.Writer, rng *rand.Rand, scale float64) error {
+	total := gen.Count(550, scale)
+	used := map[string]bool{"in": true}
+
+	// newName returns an unused name of two or more letters.
+	newName := func() string {
+		for n := 2; ; n++ {
+			for try := 0; try < 10; try++ {
+				b := make([]byte, n)
+				for i := range b {
+					b[i] = byte(gen.Between(rng, 'a', 'z'))
+				}
+
+				if name := string(b); !used[name] {
+					used[name] = true
+					return name
+				}
+			}
+		}
+	}
+
+	// target returns where a rule sends parts: a new workflow, queued to be
+	// written, while there are workflows left to create, else A or R.
+	queue := []string{"in"}
+	created := 1
+	target := func() string {
+		if created < total && rng.Intn(10) < 7 {
+			created++
+			queue = append(queue, newName())
+			return queue[len(queue)-1]
+		}
+
+		return string(gen.Pick(rng, "AR"))
+	}
+
+	var workflows []string
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		rules := make([]string, gen.Between(rng, 1, 3), 4)
+		for i := range rules {
+			rules[i] = fmt.Sprintf("%c%c%d:%s", gen.Pick(rng, categories), gen.Pick(rng, "<>"), gen.Between(rng, 2, 3999), target())
+		}
+
+		rules = append(rules, target())
+		workflows = append(workflows, fmt.Sprintf("%s{%s}", name, strings.Join(rules, ",")))
+	}
+
+	rng.Shuffle(len(workflows), func(i, j int) { workflows[i], workflows[j] = workflows[j], workflows[i] })
+
+	bw := bufio.NewWriter(w)
+	for _, wf := range workflows {
+		fmt.Fprintln(bw, wf)
+	}
+
+	bw.WriteByte('\n')
+	for i := gen.Count(200, scale); i > 0; i-- {
+		fmt.Fprintf(bw, "{x=%d,m=%d,a=%d,s=%d}\n", gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000))
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+package aplenty
+
+import (
+	"context"
+	"embed"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	aoc.Register(aoc.Day{
+		Day: 19, Title: "Aplenty", Dir: "day__19", New: New, Version: "1", Examples: examples, Generate: Generate,
+		Alternates: []aoc.Alternate{
+			{Name: "range-splitting", Parts: []int{1}, New: func() aoc.Solver { return &rangeSplitting{} }},
+		},
+	})
+}
+
+// New returns an aoc.Solver for day 19.
+func New() aoc.Solver {
+	return &solver{}
+}
+
+type solver struct {
+	system *System
+}
+
+func (s *solver) Parse(_ context.Context, input string) (err error) {
+	s.system, err = Parse(strings.NewReader(input))
+	return err
+}
+
+func (s *solver) PartOne(ctx context.Context) (any, error) {
+	return Part1(ctx, s.system)
+}
+
+func (s *solver) PartTwo(ctx context.Context) (any, error) {
+	return Part2(ctx, s.system)
+}
+
+// rangeSplitting sorts the parts by the accepted boxes of ratings instead of
+// running each part through the workflows.
+type rangeSplitting struct {
+	solver
+}
+
+func (s *rangeSplitting) PartOne(ctx context.Context) (any, error) {
+	return Part1Split(ctx, s.system)
+}
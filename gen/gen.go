@@ -0,0 +1,148 @@
+// Package gen holds the helpers shared by the days' input generators:
+// scaling real input sizes and drawing random shapes.
+package gen
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+)
+
+// Count scales a count of n items, as found in a real input, by scale. It
+// is at least 1.
+func Count(n int, scale float64) int {
+	return max(1, int(math.Round(float64(n)*scale)))
+}
+
+// Side scales the side of an n by n grid, as found in a real input, so that
+// its area grows by scale. It is at least 1.
+func Side(n int, scale float64) int {
+	return max(1, int(math.Round(float64(n)*math.Sqrt(scale))))
+}
+
+// Between returns a random integer in [lo, hi].
+func Between(rng *rand.Rand, lo, hi int) int {
+	return lo + rng.Intn(hi-lo+1)
+}
+
+// Pick returns a random byte of s.
+func Pick(rng *rand.Rand, s string) byte {
+	return s[rng.Intn(len(s))]
+}
+
+// Blob returns a random shape of about fill times the cells of a width by
+// height grid, grown from its centre. The shape is 4-connected, has no holes
+// and no two of its cells touch only at a corner, so its outline is a simple
+// closed curve.
+func Blob(rng *rand.Rand, width, height int, fill float64) *grid.Grid[bool] {
+	blob := grid.New[bool](width, height)
+	queued := grid.New[bool](width, height)
+	if width == 0 || height == 0 {
+		return blob
+	}
+
+	start := grid.Point{X: width / 2, Y: height / 2}
+	candidates := []grid.Point{start}
+	queued.Set(start, true)
+
+	target := max(1, int(fill*float64(width*height)))
+	for size := 0; size < target && len(candidates) > 0; {
+		i := rng.Intn(len(candidates))
+		p := candidates[i]
+		candidates[i] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+		queued.Set(p, false)
+
+		if size > 0 && !simple(blob, p) {
+			continue
+		}
+
+		blob.Set(p, true)
+		size++
+
+		for _, q := range blob.Neighbors4(p) {
+			if !blob.At(q) && !queued.At(q) {
+				queued.Set(q, true)
+				candidates = append(candidates, q)
+			}
+		}
+	}
+
+	return blob
+}
+
+// simple reports whether adding p to blob keeps it a shape as described by
+// Blob: the cells of blob around p must form a single run that includes an
+// orthogonal neighbour of p and does not surround it.
+func simple(blob *grid.Grid[bool], p grid.Point) bool {
+	in := func(i int) bool {
+		v, _ := blob.Get(p.Add(grid.Dirs8[i%len(grid.Dirs8)]))
+		return v
+	}
+
+	runs, cells, orthogonal := 0, 0, false
+	for i := range grid.Dirs8 {
+		if !in(i) {
+			continue
+		}
+
+		cells++
+		if i%2 == 0 {
+			orthogonal = true
+		}
+
+		if !in(i + len(grid.Dirs8) - 1) {
+			runs++
+		}
+	}
+
+	return runs == 1 && cells < len(grid.Dirs8) && orthogonal
+}
+
+// Outline returns the corners of the cells of blob, as made by Blob, that
+// lie on its outline, in clockwise order starting from the top-left corner
+// of its first cell. Corner (x, y) is the top-left corner of cell (x, y), so
+// the corners range over a (Width+1) by (Height+1) grid.
+func Outline(blob *grid.Grid[bool]) []grid.Point {
+	start, ok := grid.Find(blob, true)
+	if !ok {
+		return nil
+	}
+
+	in := func(x, y int) bool {
+		v, _ := blob.Get(grid.Point{X: x, Y: y})
+		return v
+	}
+
+	// edge reports whether the edge leaving corner c in direction d
+	// separates a cell of blob from one outside it.
+	edge := func(c, d grid.Point) bool {
+		switch d {
+		case grid.Right:
+			return in(c.X, c.Y-1) != in(c.X, c.Y)
+		case grid.Left:
+			return in(c.X-1, c.Y-1) != in(c.X-1, c.Y)
+		case grid.Down:
+			return in(c.X-1, c.Y) != in(c.X, c.Y)
+		default:
+			return in(c.X-1, c.Y-1) != in(c.X, c.Y-1)
+		}
+	}
+
+	outline := []grid.Point{start}
+	c, heading := start.Add(grid.Right), grid.Right
+	for c != start {
+		outline = append(outline, c)
+		for _, d := range grid.Dirs4 {
+			if d != heading.Mul(-1) && edge(c, d) {
+				heading = d
+				break
+			}
+		}
+
+		c = c.Add(heading)
+	}
+
+	return outline
+}
@@ -0,0 +1,3 @@
+module github.com/unkn0wn-root/advent_of_code_2023
+
+go 1.21
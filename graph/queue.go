@@ -0,0 +1,68 @@
+package graph
+
+import "container/heap"
+
+// PriorityQueue is a min-priority queue of T. The zero value is an empty queue.
+type PriorityQueue[T any] struct {
+	items items[T]
+}
+
+type item[T any] struct {
+	value    T
+	priority int
+	// order breaks ties, so that equal priorities come out first in, first out.
+	order int
+}
+
+// Len returns the number of values in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items.list)
+}
+
+// Push adds v to the queue with the given priority.
+func (q *PriorityQueue[T]) Push(v T, priority int) {
+	heap.Push(&q.items, item[T]{value: v, priority: priority, order: q.items.pushed})
+	q.items.pushed++
+}
+
+// Pop removes and returns the value with the lowest priority, and that
+// priority. It panics if the queue is empty.
+func (q *PriorityQueue[T]) Pop() (T, int) {
+	it := heap.Pop(&q.items).(item[T])
+	return it.value, it.priority
+}
+
+// items implements heap.Interface for PriorityQueue.
+type items[T any] struct {
+	list   []item[T]
+	pushed int
+}
+
+func (h items[T]) Len() int {
+	return len(h.list)
+}
+
+func (h items[T]) Less(i, j int) bool {
+	if h.list[i].priority != h.list[j].priority {
+		return h.list[i].priority < h.list[j].priority
+	}
+
+	return h.list[i].order < h.list[j].order
+}
+
+func (h items[T]) Swap(i, j int) {
+	h.list[i], h.list[j] = h.list[j], h.list[i]
+}
+
+func (h *items[T]) Push(x any) {
+	h.list = append(h.list, x.(item[T]))
+}
+
+func (h *items[T]) Pop() any {
+	n := len(h.list)
+	it := h.list[n-1]
+	h.list[n-1] = item[T]{} // do not keep the value alive
+	h.list = h.list[:n-1]
+
+	return it
+}
@@ -0,0 +1,47 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/graph"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	var q graph.PriorityQueue[string]
+	for _, it := range []struct {
+		v string
+		p int
+	}{{"c", 3}, {"a", 1}, {"d", 3}, {"b", 2}, {"e", 3}, {"z", -1}} {
+		q.Push(it.v, it.p)
+	}
+
+	if q.Len() != 6 {
+		t.Fatalf("Len() = %d, want 6", q.Len())
+	}
+
+	// equal priorities come out in the order they were pushed.
+	want := []struct {
+		v string
+		p int
+	}{{"z", -1}, {"a", 1}, {"b", 2}, {"c", 3}, {"d", 3}, {"e", 3}}
+	for _, w := range want {
+		if v, p := q.Pop(); v != w.v || p != w.p {
+			t.Errorf("Pop() = %q, %d, want %q, %d", v, p, w.v, w.p)
+		}
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after popping everything", q.Len())
+	}
+}
+
+func TestPriorityQueuePopEmptyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Pop() on an empty queue did not panic")
+		}
+	}()
+
+	var q graph.PriorityQueue[int]
+	q.Pop()
+}
@@ -0,0 +1,168 @@
+// Package graph holds the generic searches shared by the days that walk a
+// graph: BFS, Dijkstra and A* over an implicit graph, whose nodes and edges
+// are only produced as the search reaches them.
+package graph
+
+import (
+	"context"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+)
+
+// Edge leads to a neighbouring node at some cost.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Graph is an implicit graph: Neighbors returns the edges leaving n.
+type Graph[N comparable] interface {
+	Neighbors(n N) []Edge[N]
+}
+
+// Func adapts a neighbours function to the Graph interface.
+type Func[N comparable] func(n N) []Edge[N]
+
+// Neighbors returns f(n).
+func (f Func[N]) Neighbors(n N) []Edge[N] {
+	return f(n)
+}
+
+// Result is the outcome of a search: the distance to every node reached
+// and the tree of predecessors on the shortest paths to them.
+type Result[N comparable] struct {
+	Start N
+	Dist  map[N]int
+	Prev  map[N]N
+	// Goal is the goal node the search stopped at, when Found.
+	Goal  N
+	Found bool
+}
+
+// Path returns the nodes on the shortest path from the start to n, both
+// included, or nil if n was not reached.
+func (r *Result[N]) Path(n N) []N {
+	if _, ok := r.Dist[n]; !ok {
+		return nil
+	}
+
+	path := []N{n}
+	for n != r.Start {
+		n = r.Prev[n]
+		path = append(path, n)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// how many nodes a search expands between checks for cancellation.
+const checkEvery = 1024
+
+// BFS searches g breadth first from start, counting every edge as one step
+// whatever its cost. It stops at the first node for which goal returns true,
+// or explores everything reachable when goal is nil. It returns ctx.Err()
+// once ctx is done.
+func BFS[N comparable](ctx context.Context, g Graph[N], start N, goal func(N) bool) (*Result[N], error) {
+	r := &Result[N]{Start: start, Dist: map[N]int{start: 0}, Prev: map[N]N{}}
+
+	queue := []N{start}
+	for expanded := 0; len(queue) > 0; expanded++ {
+		if expanded%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			aoc.ReportProgress(ctx, "nodes expanded", expanded, 0)
+		}
+
+		n := queue[0]
+		queue = queue[1:]
+
+		if goal != nil && goal(n) {
+			r.Goal, r.Found = n, true
+			return r, nil
+		}
+
+		for _, e := range g.Neighbors(n) {
+			if _, seen := r.Dist[e.To]; seen {
+				continue
+			}
+
+			r.Dist[e.To] = r.Dist[n] + 1
+			r.Prev[e.To] = n
+			queue = append(queue, e.To)
+		}
+	}
+
+	return r, nil
+}
+
+// Dijkstra searches g for the cheapest paths from start. Edge costs must
+// not be negative. It stops once the first goal node is settled, or explores
+// everything reachable when goal is nil. It returns ctx.Err() once ctx is done.
+func Dijkstra[N comparable](ctx context.Context, g Graph[N], start N, goal func(N) bool) (*Result[N], error) {
+	return AStar(ctx, g, start, goal, nil)
+}
+
+// AStar is Dijkstra guided by the heuristic h, which must be consistent: it
+// never overestimates the remaining cost to a goal, and never drops by more
+// than the cost of an edge. A nil h makes it Dijkstra. With a heuristic,
+// only the distance to the goal is final: other distances are upper bounds.
+func AStar[N comparable](ctx context.Context, g Graph[N], start N, goal func(N) bool, h func(N) int) (*Result[N], error) {
+	r := &Result[N]{Start: start, Dist: map[N]int{start: 0}, Prev: map[N]N{}}
+	settled := make(map[N]bool)
+
+	estimate := func(n N) int {
+		if h == nil {
+			return 0
+		}
+
+		return h(n)
+	}
+
+	var queue PriorityQueue[N]
+	queue.Push(start, estimate(start))
+
+	for expanded := 0; queue.Len() > 0; {
+		n, _ := queue.Pop()
+		if settled[n] {
+			continue
+		}
+		settled[n] = true
+
+		if expanded%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			aoc.ReportProgress(ctx, "nodes settled", expanded, 0)
+		}
+		expanded++
+
+		if goal != nil && goal(n) {
+			r.Goal, r.Found = n, true
+			return r, nil
+		}
+
+		for _, e := range g.Neighbors(n) {
+			if settled[e.To] {
+				continue
+			}
+
+			dist := r.Dist[n] + e.Cost
+			if old, seen := r.Dist[e.To]; seen && old <= dist {
+				continue
+			}
+
+			r.Dist[e.To] = dist
+			r.Prev[e.To] = n
+			queue.Push(e.To, dist+estimate(e.To))
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,196 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/graph"
+)
+
+// weighted is a small directed graph; F cannot be reached from A.
+var weighted = graph.Func[string](func(n string) []graph.Edge[string] {
+	return map[string][]graph.Edge[string]{
+		"A": {{To: "B", Cost: 1}, {To: "C", Cost: 4}},
+		"B": {{To: "C", Cost: 2}, {To: "D", Cost: 5}},
+		"C": {{To: "D", Cost: 1}},
+		"D": {{To: "E", Cost: 3}},
+		"F": {{To: "A", Cost: 1}},
+	}[n]
+})
+
+// is returns a goal that is only want.
+func is[N comparable](want N) func(N) bool {
+	return func(n N) bool { return n == want }
+}
+
+func TestDijkstra(t *testing.T) {
+	r, err := graph.Dijkstra[string](context.Background(), weighted, "A", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n, want := range map[string]int{"A": 0, "B": 1, "C": 3, "D": 4, "E": 7} {
+		if got, ok := r.Dist[n]; !ok || got != want {
+			t.Errorf("Dist[%s] = %d, %t, want %d", n, got, ok, want)
+		}
+	}
+
+	if got, want := r.Path("E"), []string{"A", "B", "C", "D", "E"}; !slices.Equal(got, want) {
+		t.Errorf("Path(E) = %v, want %v", got, want)
+	}
+
+	if got := r.Path("A"); !slices.Equal(got, []string{"A"}) {
+		t.Errorf("Path(A) = %v, want [A]", got)
+	}
+
+	r, err = graph.Dijkstra[string](context.Background(), weighted, "A", is("D"))
+	if err != nil || !r.Found || r.Goal != "D" || r.Dist["D"] != 4 {
+		t.Errorf("Dijkstra to D = %+v, %v, want D at 4", r, err)
+	}
+}
+
+func TestBFS(t *testing.T) {
+	r, err := graph.BFS[string](context.Background(), weighted, "A", is("E"))
+	if err != nil || !r.Found || r.Goal != "E" {
+		t.Fatalf("BFS to E = %+v, %v, want E found", r, err)
+	}
+
+	// BFS counts steps, not costs: D is two edges away through B.
+	for n, want := range map[string]int{"A": 0, "B": 1, "C": 1, "D": 2, "E": 3} {
+		if got := r.Dist[n]; got != want {
+			t.Errorf("Dist[%s] = %d, want %d", n, got, want)
+		}
+	}
+
+	if got, want := r.Path("E"), []string{"A", "B", "D", "E"}; !slices.Equal(got, want) {
+		t.Errorf("Path(E) = %v, want %v", got, want)
+	}
+}
+
+func TestUnreachableGoal(t *testing.T) {
+	searches := map[string]func() (*graph.Result[string], error){
+		"BFS": func() (*graph.Result[string], error) {
+			return graph.BFS[string](context.Background(), weighted, "A", is("F"))
+		},
+		"Dijkstra": func() (*graph.Result[string], error) {
+			return graph.Dijkstra[string](context.Background(), weighted, "A", is("F"))
+		},
+		"AStar": func() (*graph.Result[string], error) {
+			return graph.AStar[string](context.Background(), weighted, "A", is("F"), func(string) int { return 0 })
+		},
+	}
+
+	for name, search := range searches {
+		r, err := search()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if r.Found {
+			t.Errorf("%s found unreachable F at %v", name, r.Goal)
+		}
+
+		if _, ok := r.Dist["F"]; ok || r.Path("F") != nil {
+			t.Errorf("%s has a distance or path to unreachable F", name)
+		}
+
+		if len(r.Dist) != 5 {
+			t.Errorf("%s reached %d nodes, want all 5 reachable ones", name, len(r.Dist))
+		}
+	}
+}
+
+type cell struct{ x, y int }
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	const size = 40
+
+	rng := rand.New(rand.NewSource(1))
+	cost := make(map[cell]int)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			cost[cell{x, y}] = 1 + rng.Intn(9)
+		}
+	}
+
+	g := graph.Func[cell](func(n cell) []graph.Edge[cell] {
+		var edges []graph.Edge[cell]
+		for _, d := range []cell{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			to := cell{n.x + d.x, n.y + d.y}
+			if c, ok := cost[to]; ok {
+				edges = append(edges, graph.Edge[cell]{To: to, Cost: c})
+			}
+		}
+
+		return edges
+	})
+
+	goal := cell{size - 1, size - 1}
+	// every step costs at least 1, so the Manhattan distance is consistent.
+	manhattan := func(n cell) int { return goal.x - n.x + goal.y - n.y }
+
+	dijkstra, err := graph.Dijkstra[cell](context.Background(), g, cell{}, is(goal))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	astar, err := graph.AStar[cell](context.Background(), g, cell{}, is(goal), manhattan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !astar.Found || astar.Dist[goal] != dijkstra.Dist[goal] {
+		t.Errorf("AStar found %t at %d, Dijkstra at %d", astar.Found, astar.Dist[goal], dijkstra.Dist[goal])
+	}
+
+	// the path A* reports costs what it claims.
+	path, total := astar.Path(goal), 0
+	for _, n := range path[1:] {
+		total += cost[n]
+	}
+
+	if path[0] != (cell{}) || total != astar.Dist[goal] {
+		t.Errorf("A* path from %v costs %d, want %d", path[0], total, astar.Dist[goal])
+	}
+
+	if len(astar.Dist) > len(dijkstra.Dist) {
+		t.Errorf("A* reached %d nodes, more than Dijkstra's %d", len(astar.Dist), len(dijkstra.Dist))
+	}
+}
+
+func TestSearchStopsWhenCancelled(t *testing.T) {
+	searches := map[string]func(context.Context, graph.Graph[int]) (*graph.Result[int], error){
+		"BFS": func(ctx context.Context, g graph.Graph[int]) (*graph.Result[int], error) {
+			return graph.BFS(ctx, g, 0, nil)
+		},
+		"Dijkstra": func(ctx context.Context, g graph.Graph[int]) (*graph.Result[int], error) {
+			return graph.Dijkstra(ctx, g, 0, nil)
+		},
+		"AStar": func(ctx context.Context, g graph.Graph[int]) (*graph.Result[int], error) {
+			return graph.AStar(ctx, g, 0, nil, func(int) int { return 0 })
+		},
+	}
+
+	for name, search := range searches {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// an endless line of nodes, cancelled once the search is well under way.
+		expanded := 0
+		line := graph.Func[int](func(n int) []graph.Edge[int] {
+			if expanded++; expanded == 5000 {
+				cancel()
+			}
+
+			return []graph.Edge[int]{{To: n + 1, Cost: 1}}
+		})
+
+		if r, err := search(ctx, line); !errors.Is(err, context.Canceled) || r != nil {
+			t.Errorf("%s = %v, %v, want context.Canceled", name, r, err)
+		}
+
+		cancel()
+	}
+}
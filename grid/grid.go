@@ -0,0 +1,234 @@
+// Package grid is the dense, rectangular 2D grid shared by the grid-based
+// days. Positions are image.Points with X the column and Y the row, both
+// counted from the top-left corner.
+package grid
+
+import (
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// Point is a position in a grid, or an offset between two positions.
+type Point = image.Point
+
+// the four orthogonal directions, as offsets.
+var (
+	Up    = Point{X: 0, Y: -1}
+	Right = Point{X: 1, Y: 0}
+	Down  = Point{X: 0, Y: 1}
+	Left  = Point{X: -1, Y: 0}
+)
+
+// Dirs4 lists the orthogonal directions clockwise from Up.
+var Dirs4 = []Point{Up, Right, Down, Left}
+
+// Dirs8 lists the orthogonal and diagonal directions clockwise from Up.
+var Dirs8 = []Point{Up, {X: 1, Y: -1}, Right, {X: 1, Y: 1}, Down, {X: -1, Y: 1}, Left, {X: -1, Y: -1}}
+
+// Grid is a Width by Height grid of T stored row by row.
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+}
+
+// New returns a width by height grid of zero values.
+func New[T any](width, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("grid: negative size %dx%d", width, height))
+	}
+
+	return &Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// Parse reads a grid of text from r. See FromLines.
+func Parse(r io.Reader, alphabet string) (*Grid[rune], error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromLines(lines, alphabet)
+}
+
+// FromLines returns the grid of text whose rows are lines. The lines must
+// form a rectangle of characters from alphabet, or of any character when
+// alphabet is empty.
+func FromLines(lines []string, alphabet string) (*Grid[rune], error) {
+	var err error
+	if alphabet == "" {
+		err = parse.Rectangle(lines)
+	} else {
+		err = parse.Grid(lines, alphabet)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	width := 0
+	if len(lines) > 0 {
+		width = len([]rune(lines[0]))
+	}
+
+	// parse.Rectangle has checked that every row is as wide as the first.
+	g := &Grid[rune]{Width: width, Height: len(lines), cells: make([]rune, 0, width*len(lines))}
+	for _, line := range lines {
+		g.cells = append(g.cells, []rune(line)...)
+	}
+
+	return g, nil
+}
+
+// Map returns the grid of f applied to every cell of g.
+func Map[T, U any](g *Grid[T], f func(T) U) *Grid[U] {
+	out := New[U](g.Width, g.Height)
+	for i, v := range g.cells {
+		out.cells[i] = f(v)
+	}
+
+	return out
+}
+
+// Lines returns the rows of a grid of text.
+func Lines(g *Grid[rune]) []string {
+	lines := make([]string, g.Height)
+	for y := range lines {
+		lines[y] = string(g.Row(y))
+	}
+
+	return lines
+}
+
+// In reports whether p lies inside the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// At returns the cell at p, which must lie inside the grid.
+func (g *Grid[T]) At(p Point) T {
+	return g.cells[g.index(p)]
+}
+
+// Get returns the cell at p and whether p lies inside the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[p.Y*g.Width+p.X], true
+}
+
+// Set stores v at p, which must lie inside the grid.
+func (g *Grid[T]) Set(p Point, v T) {
+	g.cells[g.index(p)] = v
+}
+
+func (g *Grid[T]) index(p Point) int {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %v outside %dx%d grid", p, g.Width, g.Height))
+	}
+
+	return p.Y*g.Width + p.X
+}
+
+// Neighbors4 returns the orthogonal neighbours of p inside the grid.
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, Dirs4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbours of p inside the grid.
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, Dirs8)
+}
+
+func (g *Grid[T]) neighbors(p Point, dirs []Point) []Point {
+	out := make([]Point, 0, len(dirs))
+	for _, d := range dirs {
+		if q := p.Add(d); g.In(q) {
+			out = append(out, q)
+		}
+	}
+
+	return out
+}
+
+// Row returns row y. It shares storage with the grid, but appending to it
+// never overwrites the next row.
+func (g *Grid[T]) Row(y int) []T {
+	return g.cells[y*g.Width : (y+1)*g.Width : (y+1)*g.Width]
+}
+
+// Col returns a copy of column x.
+func (g *Grid[T]) Col(x int) []T {
+	col := make([]T, g.Height)
+	for y := range col {
+		col[y] = g.cells[y*g.Width+x]
+	}
+
+	return col
+}
+
+// Each calls fn for every cell, row by row.
+func (g *Grid[T]) Each(fn func(p Point, v T)) {
+	for i, v := range g.cells {
+		fn(Point{X: i % g.Width, Y: i / g.Width}, v)
+	}
+}
+
+// Clone returns a copy of g.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Width: g.Width, Height: g.Height, cells: append([]T(nil), g.cells...)}
+}
+
+// Transpose returns g mirrored along its main diagonal: rows become columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: p.Y, Y: p.X} })
+}
+
+// RotateCW returns g rotated a quarter turn clockwise.
+func (g *Grid[T]) RotateCW() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: g.Height - 1 - p.Y, Y: p.X} })
+}
+
+// RotateCCW returns g rotated a quarter turn counter-clockwise.
+func (g *Grid[T]) RotateCCW() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: p.Y, Y: g.Width - 1 - p.X} })
+}
+
+// remap returns a width by height grid where the cell of g at p moves to to(p).
+func (g *Grid[T]) remap(width, height int, to func(Point) Point) *Grid[T] {
+	out := New[T](width, height)
+	g.Each(func(p Point, v T) {
+		out.Set(to(p), v)
+	})
+
+	return out
+}
+
+// Find returns the first position, row by row, holding v.
+func Find[T comparable](g *Grid[T], v T) (Point, bool) {
+	for i, c := range g.cells {
+		if c == v {
+			return Point{X: i % g.Width, Y: i / g.Width}, true
+		}
+	}
+
+	return Point{}, false
+}
+
+// FindAll returns every position holding v, row by row.
+func FindAll[T comparable](g *Grid[T], v T) []Point {
+	var out []Point
+	for i, c := range g.cells {
+		if c == v {
+			out = append(out, Point{X: i % g.Width, Y: i / g.Width})
+		}
+	}
+
+	return out
+}
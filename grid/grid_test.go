@@ -0,0 +1,220 @@
+package grid_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/grid"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// p is a shorthand for the point at column x, row y.
+func p(x, y int) grid.Point {
+	return grid.Point{X: x, Y: y}
+}
+
+func mustParse(t *testing.T, text string) *grid.Grid[rune] {
+	t.Helper()
+
+	g, err := grid.Parse(strings.NewReader(text), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestFromLines(t *testing.T) {
+	g, err := grid.FromLines([]string{"abc", "def"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Width != 3 || g.Height != 2 || g.At(p(2, 1)) != 'f' {
+		t.Errorf("FromLines gave a %dx%d grid with %q at 2,1", g.Width, g.Height, g.At(p(2, 1)))
+	}
+
+	if got := grid.Lines(g); !slices.Equal(got, []string{"abc", "def"}) {
+		t.Errorf("Lines = %q", got)
+	}
+
+	// rows are measured in characters, whatever their encoding takes.
+	g, err = grid.FromLines([]string{"é.", "..", ".é"}, "")
+	if err != nil || g.Width != 2 || g.At(p(1, 2)) != 'é' {
+		t.Errorf("FromLines of non-ASCII rows = %v, %v", g, err)
+	}
+}
+
+func TestFromLinesRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		alphabet string
+		line     int
+	}{
+		{"short row", []string{"abc", "ab", "abc"}, "", 2},
+		{"long row", []string{"abc", "abc", "abcd"}, "", 3},
+		{"short row in characters", []string{"éé", "é"}, "", 2},
+		{"short row with alphabet", []string{"..#", ".#"}, ".#", 2},
+		{"tile outside alphabet", []string{"..#", ".x#"}, ".#", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := grid.FromLines(tt.lines, tt.alphabet)
+
+			var perr *parse.Error
+			if !errors.As(err, &perr) || perr.Line != tt.line {
+				t.Errorf("FromLines(%q) error = %v, want a *parse.Error on line %d", tt.lines, err, tt.line)
+			}
+		})
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	g := mustParse(t, "abc\ndef")
+
+	tests := []struct {
+		name string
+		got  *grid.Grid[rune]
+		want []string
+	}{
+		{"Transpose", g.Transpose(), []string{"ad", "be", "cf"}},
+		{"RotateCW", g.RotateCW(), []string{"da", "eb", "fc"}},
+		{"RotateCCW", g.RotateCCW(), []string{"cf", "be", "ad"}},
+		{"RotateCW twice", g.RotateCW().RotateCW(), []string{"fed", "cba"}},
+		{"RotateCW then RotateCCW", g.RotateCW().RotateCCW(), []string{"abc", "def"}},
+		{"Transpose twice", g.Transpose().Transpose(), []string{"abc", "def"}},
+	}
+
+	for _, tt := range tests {
+		if got := grid.Lines(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := grid.Lines(g); !slices.Equal(got, []string{"abc", "def"}) {
+		t.Errorf("transformations changed the grid to %q", got)
+	}
+}
+
+func TestRowSharesStorage(t *testing.T) {
+	g := mustParse(t, "abc\ndef")
+
+	row := g.Row(0)
+	row[1] = 'X'
+	if g.At(p(1, 0)) != 'X' {
+		t.Errorf("writing to Row(0) did not change the grid")
+	}
+
+	_ = append(row, 'Y')
+	if got := string(g.Row(1)); got != "def" {
+		t.Errorf("appending to Row(0) changed row 1 to %q", got)
+	}
+
+	col := g.Col(0)
+	col[0] = 'Z'
+	if g.At(p(0, 0)) != 'a' {
+		t.Errorf("writing to Col(0) changed the grid")
+	}
+
+	clone := g.Clone()
+	clone.Set(p(0, 0), 'Z')
+	if g.At(p(0, 0)) != 'a' {
+		t.Errorf("writing to a Clone changed the grid")
+	}
+}
+
+func TestInAndGet(t *testing.T) {
+	g := grid.New[int](3, 2)
+
+	for q, want := range map[grid.Point]bool{
+		p(0, 0): true, p(2, 1): true, p(3, 0): false, p(0, 2): false, p(-1, 0): false, p(0, -1): false,
+	} {
+		if got := g.In(q); got != want {
+			t.Errorf("In(%v) = %t, want %t", q, got, want)
+		}
+
+		if _, ok := g.Get(q); ok != want {
+			t.Errorf("Get(%v) ok = %t, want %t", q, ok, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At outside the grid did not panic")
+		}
+	}()
+
+	g.At(p(3, 0))
+}
+
+func TestNeighbors(t *testing.T) {
+	g := grid.New[int](3, 3)
+
+	tests := []struct {
+		name  string
+		at    grid.Point
+		four  []grid.Point
+		eight []grid.Point
+	}{
+		{"top-left corner", p(0, 0), []grid.Point{p(1, 0), p(0, 1)}, []grid.Point{p(1, 0), p(1, 1), p(0, 1)}},
+		{"bottom-right corner", p(2, 2), []grid.Point{p(2, 1), p(1, 2)}, []grid.Point{p(2, 1), p(1, 2), p(1, 1)}},
+		{"top edge", p(1, 0), []grid.Point{p(2, 0), p(1, 1), p(0, 0)}, []grid.Point{p(2, 0), p(2, 1), p(1, 1), p(0, 1), p(0, 0)}},
+		{"left edge", p(0, 1), []grid.Point{p(0, 0), p(1, 1), p(0, 2)}, []grid.Point{p(0, 0), p(1, 0), p(1, 1), p(1, 2), p(0, 2)}},
+		{
+			"middle", p(1, 1),
+			[]grid.Point{p(1, 0), p(2, 1), p(1, 2), p(0, 1)},
+			[]grid.Point{p(1, 0), p(2, 0), p(2, 1), p(2, 2), p(1, 2), p(0, 2), p(0, 1), p(0, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		// both list the neighbours clockwise from the one above.
+		if got := g.Neighbors4(tt.at); !slices.Equal(got, tt.four) {
+			t.Errorf("%s: Neighbors4(%v) = %v, want %v", tt.name, tt.at, got, tt.four)
+		}
+
+		if got := g.Neighbors8(tt.at); !slices.Equal(got, tt.eight) {
+			t.Errorf("%s: Neighbors8(%v) = %v, want %v", tt.name, tt.at, got, tt.eight)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := mustParse(t, ".*.\n*..\n..*")
+
+	if got, want := grid.FindAll(g, '*'), []grid.Point{p(1, 0), p(0, 1), p(2, 2)}; !slices.Equal(got, want) {
+		t.Errorf("FindAll(*) = %v, want %v in row order", got, want)
+	}
+
+	if got := grid.FindAll(g, '#'); got != nil {
+		t.Errorf("FindAll(#) = %v, want nil", got)
+	}
+
+	if got, ok := grid.Find(g, '*'); !ok || got != p(1, 0) {
+		t.Errorf("Find(*) = %v, %t, want 1,0", got, ok)
+	}
+
+	if _, ok := grid.Find(g, '#'); ok {
+		t.Errorf("Find(#) found a tile that is not there")
+	}
+}
+
+func TestMapAndEach(t *testing.T) {
+	g := mustParse(t, "12\n34")
+	digits := grid.Map(g, func(r rune) int { return int(r - '0') })
+
+	sum := 0
+	var order []grid.Point
+	digits.Each(func(q grid.Point, v int) {
+		sum += v
+		order = append(order, q)
+	})
+
+	if sum != 10 || !slices.Equal(order, []grid.Point{p(0, 0), p(1, 0), p(0, 1), p(1, 1)}) {
+		t.Errorf("Each visited %v summing to %d, want row order summing to 10", order, sum)
+	}
+}
@@ -0,0 +1,132 @@
+// Package input resolves where puzzle input is read from: a single file,
+// standard input, or a directory holding a batch of inputs.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+const (
+	// DefaultFile is the input every day reads when no other source is given.
+	DefaultFile = "input.txt"
+	// Stdin is the path that selects standard input.
+	Stdin = "-"
+)
+
+// Source is a single puzzle input.
+type Source struct {
+	// Name labels the source in reports: the file path, or "stdin".
+	Name string
+	path string
+}
+
+// Resolve expands path into the inputs it names. "-" is standard input,
+// a directory yields every *.txt file directly inside it sorted by name,
+// and anything else is a single file.
+func Resolve(path string) ([]Source, error) {
+	if path == Stdin {
+		return []Source{{Name: "stdin", path: Stdin}}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("input: %w", err)
+	}
+
+	if !info.IsDir() {
+		return []Source{{Name: path, path: path}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("input: %w", err)
+	}
+
+	var sources []Source
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || filepath.Ext(e.Name()) != ".txt" {
+			continue
+		}
+
+		p := filepath.Join(path, e.Name())
+		sources = append(sources, Source{Name: p, path: p})
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("input: no *.txt inputs in directory %s", path)
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+
+	return sources, nil
+}
+
+// Open opens the source for reading. The caller must close it.
+func (s Source) Open() (io.ReadCloser, error) {
+	if s.path == Stdin {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("input: %w", err)
+	}
+
+	return f, nil
+}
+
+// Read returns the content of the source normalized by Load.
+func (s Source) Read() (string, error) {
+	r, err := s.Open()
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close()
+
+	return Load(r)
+}
+
+// Each opens every source named by path and calls fn with it. When there is
+// more than one source a header naming it is written to w before each call.
+// Processing stops at the first error, which names the failing source.
+func Each(path string, w io.Writer, fn func(r io.Reader) error) error {
+	sources, err := Resolve(path)
+	if err != nil {
+		return err
+	}
+
+	for i, src := range sources {
+		if len(sources) > 1 {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+
+			fmt.Fprintf(w, "==> %s <==\n", src.Name)
+		}
+
+		if err := each(src, fn); err != nil {
+			return parse.InFile(err, src.Name)
+		}
+	}
+
+	return nil
+}
+
+func each(src Source, fn func(r io.Reader) error) error {
+	r, err := src.Open()
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	return fn(r)
+}
@@ -0,0 +1,104 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrEmpty is returned when the input holds nothing but whitespace.
+var ErrEmpty = errors.New("input: puzzle input is empty")
+
+// EncodingError reports input that is not valid UTF-8 text.
+type EncodingError struct {
+	Line, Column int
+	Reason       string
+}
+
+func (e *EncodingError) Error() string {
+	if e.Line == 0 {
+		return "input: " + e.Reason
+	}
+
+	return fmt.Sprintf("input: line %d, column %d: %s", e.Line, e.Column, e.Reason)
+}
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// Load reads all of r and normalizes it for the parsers: a UTF-8 byte order
+// mark is dropped, CRLF line endings become LF, trailing whitespace is
+// removed from every line and trailing blank lines are dropped. The result
+// never ends with a newline.
+func Load(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("input: reading: %w", err)
+	}
+
+	if bytes.HasPrefix(content, bomUTF16LE) || bytes.HasPrefix(content, bomUTF16BE) {
+		return "", &EncodingError{Reason: "input is UTF-16 encoded, convert it to UTF-8"}
+	}
+
+	content = bytes.TrimPrefix(content, bomUTF8)
+
+	if err := checkUTF8(content); err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return "", ErrEmpty
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Lines is Load split into lines.
+func Lines(r io.Reader) ([]string, error) {
+	content, err := Load(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(content, "\n"), nil
+}
+
+// checkUTF8 returns an EncodingError pointing at the first invalid byte.
+func checkUTF8(content []byte) error {
+	if utf8.Valid(content) {
+		return nil
+	}
+
+	line, column := 1, 1
+	for len(content) > 0 {
+		r, size := utf8.DecodeRune(content)
+		if r == utf8.RuneError && size == 1 {
+			return &EncodingError{Line: line, Column: column, Reason: fmt.Sprintf("invalid UTF-8 byte %#x", content[0])}
+		}
+
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+
+		content = content[size:]
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package input_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"unchanged", "ab\ncd", "ab\ncd"},
+		{"final newline", "ab\ncd\n", "ab\ncd"},
+		{"CRLF", "ab\r\ncd\r\n", "ab\ncd"},
+		{"mixed endings", "ab\r\ncd\nef", "ab\ncd\nef"},
+		{"trailing blank lines", "ab\ncd\n\n \n\t\n", "ab\ncd"},
+		{"trailing whitespace", "ab \t\ncd  \r\n", "ab\ncd"},
+		{"leading whitespace kept", "  ab\n\tcd", "  ab\n\tcd"},
+		{"inner blank line kept", "ab\n\ncd", "ab\n\ncd"},
+		{"leading blank line kept", "\nab", "\nab"},
+		{"byte order mark", "\xEF\xBB\xBFab\ncd", "ab\ncd"},
+		{"non-ASCII", "fünf\r\npięć\n", "fünf\npięć"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := input.Load(strings.NewReader(tt.input))
+			if err != nil || got != tt.want {
+				t.Errorf("Load(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		line, column int // 0 when the error is not an *input.EncodingError
+		err          error
+	}{
+		{"empty", "", 0, 0, input.ErrEmpty},
+		{"blank", " \r\n\t\n\n", 0, 0, input.ErrEmpty},
+		{"only a byte order mark", "\xEF\xBB\xBF\n", 0, 0, input.ErrEmpty},
+		{"invalid UTF-8", "ab\ncd\xFFe", 2, 3, nil},
+		{"truncated rune", "ab\nf\xC3", 2, 2, nil},
+		{"invalid UTF-8 after a byte order mark", "\xEF\xBB\xBF\xFF", 1, 1, nil},
+		{"UTF-16 little endian", "\xFF\xFEa\x00", 0, 0, nil},
+		{"UTF-16 big endian", "\xFE\xFF\x00a", 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := input.Load(strings.NewReader(tt.input))
+
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Load(%q) error = %v, want %v", tt.input, err, tt.err)
+				}
+
+				return
+			}
+
+			var encErr *input.EncodingError
+			if !errors.As(err, &encErr) || encErr.Line != tt.line || encErr.Column != tt.column {
+				t.Errorf("Load(%q) error = %v, want an *input.EncodingError at line %d, column %d", tt.input, err, tt.line, tt.column)
+			}
+		})
+	}
+
+	boom := errors.New("boom")
+	if _, err := input.Load(iotest.ErrReader(boom)); !errors.Is(err, boom) {
+		t.Errorf("Load of a failing reader: error = %v, want %v", err, boom)
+	}
+}
+
+func TestLines(t *testing.T) {
+	got, err := input.Lines(strings.NewReader("ab\r\n\ncd \n\n"))
+	if want := []string{"ab", "", "cd"}; err != nil || !slices.Equal(got, want) {
+		t.Errorf("Lines = %q, %v, want %q", got, err, want)
+	}
+}
@@ -0,0 +1,180 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Stream reads r in chunks of about size bytes, each ending on a line
+// boundary, and hands them to fn from workers goroutines at once, so that
+// inputs far larger than memory can be processed in parallel. worker tells
+// fn which goroutine is calling, from 0 to workers-1, so it can keep its
+// partial results without locking.
+//
+// A chunk holds whole lines, each ending in a newline except for the last
+// line of the input. Unlike Load, Stream leaves line endings and trailing
+// whitespace as they are; it only drops a UTF-8 byte order mark and checks
+// that the input is UTF-8 text that is not blank. A chunk is only valid
+// during the call to fn and the chunks are processed in no particular
+// order. A line longer than size makes its chunk larger.
+//
+// Stream returns the first error of fn, of reading r or from ctx, after
+// every worker has stopped.
+func Stream(ctx context.Context, r io.Reader, size, workers int, fn func(worker int, chunk []byte) error) error {
+	workers = max(workers, 1)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	type chunk struct {
+		data []byte
+		line int
+	}
+
+	// Every buffer is either free, being filled or being processed: one per
+	// worker, one to fill while they are busy and one for the next chunk.
+	free := make(chan []byte, workers+2)
+	for i := 0; i < cap(free); i++ {
+		free <- make([]byte, size)
+	}
+
+	chunks := make(chan chunk)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			for c := range chunks {
+				// once a chunk has failed, the rest are only drained.
+				if ctx.Err() != nil {
+					free <- c.data[:cap(c.data)]
+					continue
+				}
+
+				err := checkUTF8(c.data)
+				if err != nil {
+					err.(*EncodingError).Line += c.line - 1
+				} else {
+					err = fn(worker, c.data)
+				}
+
+				if err != nil {
+					cancel(err)
+				}
+
+				free <- c.data[:cap(c.data)]
+			}
+		}(i)
+	}
+
+	err := split(ctx, r, free, func(data []byte, line int) bool {
+		select {
+		case chunks <- chunk{data, line}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+
+	close(chunks)
+	wg.Wait()
+
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+
+	return err
+}
+
+// split fills the buffers of free from r and sends them on, cut after the
+// last newline they hold, together with the number of their first line. The
+// rest of a buffer starts the next one.
+func split(ctx context.Context, r io.Reader, free chan []byte, send func(data []byte, line int) bool) error {
+	var (
+		buf   []byte
+		n     int
+		line  = 1
+		first = true
+		blank = true
+	)
+
+	select {
+	case buf = <-free:
+	case <-ctx.Done():
+		return nil
+	}
+
+	for {
+		if n == len(buf) {
+			bigger := make([]byte, 2*len(buf))
+			copy(bigger, buf)
+			buf = bigger
+		}
+
+		m, err := io.ReadFull(r, buf[n:])
+		n += m
+
+		eof := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !eof {
+			return fmt.Errorf("input: reading: %w", err)
+		}
+
+		if first {
+			if bytes.HasPrefix(buf[:n], bomUTF16LE) || bytes.HasPrefix(buf[:n], bomUTF16BE) {
+				return &EncodingError{Reason: "input is UTF-16 encoded, convert it to UTF-8"}
+			}
+
+			if bytes.HasPrefix(buf[:n], bomUTF8) {
+				n = copy(buf, buf[len(bomUTF8):n])
+			}
+
+			first = false
+		}
+
+		cut := n
+		if !eof {
+			cut = bytes.LastIndexByte(buf[:n], '\n') + 1
+			if cut == 0 {
+				continue
+			}
+		}
+
+		var next []byte
+		if !eof {
+			select {
+			case next = <-free:
+			case <-ctx.Done():
+				return nil
+			}
+
+			if len(next) < n-cut {
+				next = make([]byte, len(buf))
+			}
+
+			copy(next, buf[cut:n])
+		}
+
+		blank = blank && len(bytes.TrimSpace(buf[:cut])) == 0
+		lines := bytes.Count(buf[:cut], []byte{'\n'})
+
+		if cut > 0 && !send(buf[:cut], line) {
+			return nil
+		}
+
+		if eof {
+			if blank {
+				return ErrEmpty
+			}
+
+			return nil
+		}
+
+		buf, n, line = next, n-cut, line+lines
+	}
+}
@@ -0,0 +1,141 @@
+package input_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+)
+
+// streamLines streams text in chunks of size bytes on workers goroutines and
+// returns every line it was handed, sorted, as chunks arrive in no
+// particular order. Only the chunk holding the last line may end without a
+// newline.
+func streamLines(t *testing.T, text string, size, workers int) ([]string, error) {
+	t.Helper()
+
+	var (
+		mu           sync.Mutex
+		lines        []string
+		unterminated int
+	)
+
+	err := input.Stream(context.Background(), strings.NewReader(text), size, workers, func(worker int, chunk []byte) error {
+		if worker < 0 || worker >= workers {
+			t.Errorf("fn called by worker %d of %d", worker, workers)
+		}
+
+		s := string(chunk)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !strings.HasSuffix(s, "\n") {
+			unterminated++
+		}
+
+		lines = append(lines, strings.Split(strings.TrimSuffix(s, "\n"), "\n")...)
+
+		return nil
+	})
+
+	if unterminated > 1 {
+		t.Errorf("%d chunks do not end in a newline, want at most 1", unterminated)
+	}
+
+	slices.Sort(lines)
+
+	return lines, err
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"lines split across chunks", "alpha\nbeta\ngamma\ndelta\nepsilon\nzeta\n", []string{"alpha", "beta", "delta", "epsilon", "gamma", "zeta"}},
+		{"no final newline", "one\ntwo\nthree", []string{"one", "three", "two"}},
+		{"line longer than a chunk", "a\nabcdefghijklmnopqrstuvwxyz\nb\n", []string{"a", "abcdefghijklmnopqrstuvwxyz", "b"}},
+		{"byte order mark", "\xEF\xBB\xBFfirst\nsecond\n", []string{"first", "second"}},
+		{"endings kept", "x \r\ny\t\n", []string{"x \r", "y\t"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, workers := range []int{1, 3} {
+				got, err := streamLines(t, tt.text, 8, workers)
+				if err != nil || !slices.Equal(got, tt.want) {
+					t.Errorf("Stream on %d workers = %q, %v, want %q", workers, got, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		line, column int // 0 when the error is not an *input.EncodingError
+		err          error
+	}{
+		{"blank", " \n\t\n\n", 0, 0, input.ErrEmpty},
+		{"empty", "", 0, 0, input.ErrEmpty},
+		{"invalid UTF-8 in the first chunk", "ab\xFF\n", 1, 3, nil},
+		{"invalid UTF-8 past the first chunk", strings.Repeat("abcd\n", 6) + "ab\xFFd\n" + "abcd\n", 7, 3, nil},
+		{"UTF-16", "\xFF\xFEa\x00\n\x00", 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := streamLines(t, tt.text, 8, 2)
+
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("error = %v, want %v", err, tt.err)
+				}
+
+				return
+			}
+
+			var encErr *input.EncodingError
+			if !errors.As(err, &encErr) || encErr.Line != tt.line || encErr.Column != tt.column {
+				t.Errorf("error = %v, want an *input.EncodingError at line %d, column %d", err, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestStreamStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	text := strings.Repeat("line\n", 1000)
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+
+	err := input.Stream(context.Background(), strings.NewReader(text), 8, 2, func(_ int, _ []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+
+		return boom
+	})
+
+	if !errors.Is(err, boom) {
+		t.Errorf("error = %v, want %v", err, boom)
+	}
+
+	// each worker may have started on a chunk before the first one failed,
+	// but none starts another after it.
+	if calls > 2 {
+		t.Errorf("fn was called %d times on 2 workers, want it to stop after the first error", calls)
+	}
+}
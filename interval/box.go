@@ -0,0 +1,71 @@
+package interval
+
+// Box is an N-dimensional hyperrectangle: the points whose every coordinate
+// lies in the interval of its dimension.
+type Box []Interval
+
+// Empty reports whether b holds no points.
+func (b Box) Empty() bool {
+	for _, iv := range b {
+		if iv.Empty() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Volume returns the number of points in b.
+func (b Box) Volume() int {
+	if len(b) == 0 || b.Empty() {
+		return 0
+	}
+
+	v := 1
+	for _, iv := range b {
+		v *= iv.Len()
+	}
+
+	return v
+}
+
+// Contains reports whether the point with the given coordinates lies in b.
+func (b Box) Contains(point []int) bool {
+	if len(point) != len(b) {
+		return false
+	}
+
+	for i, iv := range b {
+		if !iv.Contains(point[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Intersect returns the points in both b and o, which must have the same
+// number of dimensions.
+func (b Box) Intersect(o Box) Box {
+	out := make(Box, len(b))
+	for i := range b {
+		out[i] = b[i].Intersect(o[i])
+	}
+
+	return out
+}
+
+// With returns a copy of b whose interval in dimension dim is iv.
+func (b Box) With(dim int, iv Interval) Box {
+	out := append(Box(nil), b...)
+	out[dim] = iv
+
+	return out
+}
+
+// SplitAt cuts b across dimension dim at x, returning the points whose
+// coordinate in dim is below x and those from x on. Either may be empty.
+func (b Box) SplitAt(dim, x int) (below, above Box) {
+	lo, hi := b[dim].SplitAt(x)
+	return b.With(dim, lo), b.With(dim, hi)
+}
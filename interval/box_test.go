@@ -0,0 +1,92 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/interval"
+)
+
+func TestBoxVolumeAndEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		box    interval.Box
+		empty  bool
+		volume int
+	}{
+		{"no dimensions", nil, false, 0},
+		{"square", interval.Box{iv(0, 3), iv(0, 3)}, false, 9},
+		{"empty dimension", interval.Box{iv(0, 3), iv(2, 2), iv(0, 4)}, true, 0},
+		{"xmas ratings", interval.Box{interval.Of(1, 4000), interval.Of(1, 4000), interval.Of(1, 4000), interval.Of(1, 4000)}, false, 4000 * 4000 * 4000 * 4000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.box.Empty(); got != tt.empty {
+				t.Errorf("%v.Empty() = %t, want %t", tt.box, got, tt.empty)
+			}
+
+			if got := tt.box.Volume(); got != tt.volume {
+				t.Errorf("%v.Volume() = %d, want %d", tt.box, got, tt.volume)
+			}
+		})
+	}
+}
+
+func TestBoxSplitAt(t *testing.T) {
+	box := interval.Box{iv(0, 10), iv(5, 15)}
+
+	tests := []struct {
+		name         string
+		dim, x       int
+		below, above interval.Box
+	}{
+		{"inside first", 0, 4, interval.Box{iv(0, 4), iv(5, 15)}, interval.Box{iv(4, 10), iv(5, 15)}},
+		{"inside second", 1, 12, interval.Box{iv(0, 10), iv(5, 12)}, interval.Box{iv(0, 10), iv(12, 15)}},
+		{"at the low edge", 1, 5, interval.Box{iv(0, 10), iv(5, 5)}, interval.Box{iv(0, 10), iv(5, 15)}},
+		{"past the high edge", 0, 20, interval.Box{iv(0, 10), iv(5, 15)}, interval.Box{iv(10, 10), iv(5, 15)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			below, above := box.SplitAt(tt.dim, tt.x)
+			if !slices.Equal(below, tt.below) || !slices.Equal(above, tt.above) {
+				t.Errorf("SplitAt(%d, %d) = %v, %v, want %v, %v", tt.dim, tt.x, below, above, tt.below, tt.above)
+			}
+
+			if below.Volume()+above.Volume() != box.Volume() {
+				t.Errorf("SplitAt(%d, %d) pieces hold %d + %d points, want %d", tt.dim, tt.x, below.Volume(), above.Volume(), box.Volume())
+			}
+		})
+	}
+
+	if box[0] != iv(0, 10) || box[1] != iv(5, 15) {
+		t.Errorf("SplitAt changed the box to %v", box)
+	}
+}
+
+func TestBoxContainsAndIntersect(t *testing.T) {
+	a := interval.Box{iv(0, 10), iv(0, 10)}
+	b := interval.Box{iv(5, 15), iv(8, 20)}
+
+	if got, want := a.Intersect(b), (interval.Box{iv(5, 10), iv(8, 10)}); !slices.Equal(got, want) {
+		t.Errorf("%v.Intersect(%v) = %v, want %v", a, b, got, want)
+	}
+
+	tests := []struct {
+		point []int
+		want  bool
+	}{
+		{[]int{0, 0}, true},
+		{[]int{9, 9}, true},
+		{[]int{10, 0}, false},
+		{[]int{0, -1}, false},
+		{[]int{1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := a.Contains(tt.point); got != tt.want {
+			t.Errorf("%v.Contains(%v) = %t, want %t", a, tt.point, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package interval is integer interval arithmetic: half-open intervals, sets
+// of them, and N-dimensional boxes made of one interval per dimension.
+package interval
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interval is the half-open range [Lo, Hi). It is empty when Hi <= Lo.
+type Interval struct {
+	Lo, Hi int
+}
+
+// Of returns the interval of the n integers starting at lo.
+func Of(lo, n int) Interval {
+	return Interval{Lo: lo, Hi: lo + n}
+}
+
+func (iv Interval) String() string {
+	return fmt.Sprintf("[%d,%d)", iv.Lo, iv.Hi)
+}
+
+// Empty reports whether iv holds no integers.
+func (iv Interval) Empty() bool {
+	return iv.Hi <= iv.Lo
+}
+
+// Len returns the number of integers in iv.
+func (iv Interval) Len() int {
+	if iv.Empty() {
+		return 0
+	}
+
+	return iv.Hi - iv.Lo
+}
+
+// Contains reports whether x lies in iv.
+func (iv Interval) Contains(x int) bool {
+	return iv.Lo <= x && x < iv.Hi
+}
+
+// Intersect returns the integers in both iv and o.
+func (iv Interval) Intersect(o Interval) Interval {
+	return Interval{Lo: max(iv.Lo, o.Lo), Hi: min(iv.Hi, o.Hi)}
+}
+
+// Shift returns iv moved by d.
+func (iv Interval) Shift(d int) Interval {
+	return Interval{Lo: iv.Lo + d, Hi: iv.Hi + d}
+}
+
+// SplitAt returns the parts of iv below x and from x on. Either may be empty.
+func (iv Interval) SplitAt(x int) (below, above Interval) {
+	x = min(max(x, iv.Lo), iv.Hi)
+	return Interval{Lo: iv.Lo, Hi: x}, Interval{Lo: x, Hi: iv.Hi}
+}
+
+// Set is a set of integers held as sorted, disjoint and non-adjacent
+// intervals. Build one with NewSet; the zero value is the empty set.
+type Set []Interval
+
+// NewSet returns the union of ivs.
+func NewSet(ivs ...Interval) Set {
+	sorted := make([]Interval, 0, len(ivs))
+	for _, iv := range ivs {
+		if !iv.Empty() {
+			sorted = append(sorted, iv)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Lo < sorted[j].Lo })
+
+	var s Set
+	for _, iv := range sorted {
+		if n := len(s); n > 0 && iv.Lo <= s[n-1].Hi {
+			s[n-1].Hi = max(s[n-1].Hi, iv.Hi)
+			continue
+		}
+
+		s = append(s, iv)
+	}
+
+	return s
+}
+
+// Len returns the number of integers in s.
+func (s Set) Len() int {
+	n := 0
+	for _, iv := range s {
+		n += iv.Len()
+	}
+
+	return n
+}
+
+// Contains reports whether x is in s.
+func (s Set) Contains(x int) bool {
+	i := sort.Search(len(s), func(i int) bool { return s[i].Hi > x })
+	return i < len(s) && s[i].Contains(x)
+}
+
+// Min returns the smallest integer in s, if s is not empty.
+func (s Set) Min() (int, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	return s[0].Lo, true
+}
+
+// Union returns the integers in s or o.
+func (s Set) Union(o Set) Set {
+	return NewSet(append(append([]Interval(nil), s...), o...)...)
+}
+
+// Intersect returns the integers in both s and o.
+func (s Set) Intersect(o Set) Set {
+	var out Set
+	for i, j := 0, 0; i < len(s) && j < len(o); {
+		if iv := s[i].Intersect(o[j]); !iv.Empty() {
+			out = append(out, iv)
+		}
+
+		if s[i].Hi < o[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return out
+}
+
+// Difference returns the integers in s but not in o.
+func (s Set) Difference(o Set) Set {
+	var out Set
+	j := 0
+	for _, iv := range s {
+		for j < len(o) && o[j].Hi <= iv.Lo {
+			j++
+		}
+
+		for k := j; k < len(o) && o[k].Lo < iv.Hi; k++ {
+			var below Interval
+			below, iv = iv.SplitAt(o[k].Lo)
+			if !below.Empty() {
+				out = append(out, below)
+			}
+
+			_, iv = iv.SplitAt(o[k].Hi)
+		}
+
+		if !iv.Empty() {
+			out = append(out, iv)
+		}
+	}
+
+	return out
+}
+
+// Shift returns s moved by d.
+func (s Set) Shift(d int) Set {
+	out := make(Set, len(s))
+	for i, iv := range s {
+		out[i] = iv.Shift(d)
+	}
+
+	return out
+}
+
+// SplitAt returns the integers of s below x and from x on.
+func (s Set) SplitAt(x int) (below, above Set) {
+	for _, iv := range s {
+		b, a := iv.SplitAt(x)
+		if !b.Empty() {
+			below = append(below, b)
+		}
+
+		if !a.Empty() {
+			above = append(above, a)
+		}
+	}
+
+	return below, above
+}
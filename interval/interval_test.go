@@ -0,0 +1,222 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/interval"
+)
+
+// iv is a shorthand for the interval [lo, hi).
+func iv(lo, hi int) interval.Interval {
+	return interval.Interval{Lo: lo, Hi: hi}
+}
+
+func TestIntervalEmptyAndLen(t *testing.T) {
+	tests := []struct {
+		iv    interval.Interval
+		empty bool
+		len   int
+	}{
+		{iv(0, 0), true, 0},
+		{iv(5, 3), true, 0},
+		{iv(3, 4), false, 1},
+		{interval.Of(10, 5), false, 5},
+		{interval.Of(10, 0), true, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.iv.Empty(); got != tt.empty {
+			t.Errorf("%v.Empty() = %t, want %t", tt.iv, got, tt.empty)
+		}
+
+		if got := tt.iv.Len(); got != tt.len {
+			t.Errorf("%v.Len() = %d, want %d", tt.iv, got, tt.len)
+		}
+	}
+}
+
+func TestIntervalSplitAt(t *testing.T) {
+	tests := []struct {
+		iv           interval.Interval
+		x            int
+		below, above interval.Interval
+	}{
+		{iv(0, 10), 4, iv(0, 4), iv(4, 10)},
+		{iv(0, 10), 0, iv(0, 0), iv(0, 10)},
+		{iv(0, 10), 10, iv(0, 10), iv(10, 10)},
+		{iv(0, 10), -5, iv(0, 0), iv(0, 10)},
+		{iv(0, 10), 15, iv(0, 10), iv(10, 10)},
+	}
+
+	for _, tt := range tests {
+		below, above := tt.iv.SplitAt(tt.x)
+		if below != tt.below || above != tt.above {
+			t.Errorf("%v.SplitAt(%d) = %v, %v, want %v, %v", tt.iv, tt.x, below, above, tt.below, tt.above)
+		}
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	tests := []struct {
+		name string
+		ivs  []interval.Interval
+		want interval.Set
+	}{
+		{"nothing", nil, nil},
+		{"only empty intervals", []interval.Interval{iv(3, 3), iv(5, 1)}, nil},
+		{"disjoint, unsorted", []interval.Interval{iv(10, 12), iv(0, 2)}, interval.Set{iv(0, 2), iv(10, 12)}},
+		{"adjacent merge", []interval.Interval{iv(0, 5), iv(5, 8)}, interval.Set{iv(0, 8)}},
+		{"overlapping merge", []interval.Interval{iv(0, 5), iv(3, 8), iv(7, 9)}, interval.Set{iv(0, 9)}},
+		{"contained", []interval.Interval{iv(0, 10), iv(2, 3)}, interval.Set{iv(0, 10)}},
+		{"one apart stays apart", []interval.Interval{iv(0, 5), iv(6, 8)}, interval.Set{iv(0, 5), iv(6, 8)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := interval.NewSet(tt.ivs...); !slices.Equal(got, tt.want) {
+				t.Errorf("NewSet(%v) = %v, want %v", tt.ivs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name                         string
+		s, o                         interval.Set
+		union, intersect, difference interval.Set
+	}{
+		{
+			name:       "both empty",
+			union:      nil,
+			intersect:  nil,
+			difference: nil,
+		},
+		{
+			name:       "empty other",
+			s:          interval.NewSet(iv(0, 5)),
+			union:      interval.Set{iv(0, 5)},
+			intersect:  nil,
+			difference: interval.Set{iv(0, 5)},
+		},
+		{
+			name:       "empty self",
+			o:          interval.NewSet(iv(0, 5)),
+			union:      interval.Set{iv(0, 5)},
+			intersect:  nil,
+			difference: nil,
+		},
+		{
+			name:       "disjoint",
+			s:          interval.NewSet(iv(0, 3)),
+			o:          interval.NewSet(iv(5, 8)),
+			union:      interval.Set{iv(0, 3), iv(5, 8)},
+			intersect:  nil,
+			difference: interval.Set{iv(0, 3)},
+		},
+		{
+			name:       "adjacent",
+			s:          interval.NewSet(iv(0, 5)),
+			o:          interval.NewSet(iv(5, 8)),
+			union:      interval.Set{iv(0, 8)},
+			intersect:  nil,
+			difference: interval.Set{iv(0, 5)},
+		},
+		{
+			name:       "overlapping",
+			s:          interval.NewSet(iv(0, 6)),
+			o:          interval.NewSet(iv(4, 10)),
+			union:      interval.Set{iv(0, 10)},
+			intersect:  interval.Set{iv(4, 6)},
+			difference: interval.Set{iv(0, 4)},
+		},
+		{
+			name:       "hole punched in the middle",
+			s:          interval.NewSet(iv(0, 10)),
+			o:          interval.NewSet(iv(3, 5), iv(7, 8)),
+			union:      interval.Set{iv(0, 10)},
+			intersect:  interval.Set{iv(3, 5), iv(7, 8)},
+			difference: interval.Set{iv(0, 3), iv(5, 7), iv(8, 10)},
+		},
+		{
+			name:       "one spans several",
+			s:          interval.NewSet(iv(0, 2), iv(4, 6), iv(8, 10)),
+			o:          interval.NewSet(iv(1, 9)),
+			union:      interval.Set{iv(0, 10)},
+			intersect:  interval.Set{iv(1, 2), iv(4, 6), iv(8, 9)},
+			difference: interval.Set{iv(0, 1), iv(9, 10)},
+		},
+		{
+			name:       "equal",
+			s:          interval.NewSet(iv(2, 4)),
+			o:          interval.NewSet(iv(2, 4)),
+			union:      interval.Set{iv(2, 4)},
+			intersect:  interval.Set{iv(2, 4)},
+			difference: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Union(tt.o); !slices.Equal(got, tt.union) {
+				t.Errorf("%v.Union(%v) = %v, want %v", tt.s, tt.o, got, tt.union)
+			}
+
+			if got := tt.s.Intersect(tt.o); !slices.Equal(got, tt.intersect) {
+				t.Errorf("%v.Intersect(%v) = %v, want %v", tt.s, tt.o, got, tt.intersect)
+			}
+
+			if got := tt.s.Difference(tt.o); !slices.Equal(got, tt.difference) {
+				t.Errorf("%v.Difference(%v) = %v, want %v", tt.s, tt.o, got, tt.difference)
+			}
+		})
+	}
+}
+
+func TestSetLenContainsMin(t *testing.T) {
+	s := interval.NewSet(iv(0, 3), iv(10, 12))
+
+	if got := s.Len(); got != 5 {
+		t.Errorf("%v.Len() = %d, want 5", s, got)
+	}
+
+	for x, want := range map[int]bool{-1: false, 0: true, 2: true, 3: false, 9: false, 10: true, 11: true, 12: false} {
+		if got := s.Contains(x); got != want {
+			t.Errorf("%v.Contains(%d) = %t, want %t", s, x, got, want)
+		}
+	}
+
+	if lo, ok := s.Min(); !ok || lo != 0 {
+		t.Errorf("%v.Min() = %d, %t, want 0, true", s, lo, ok)
+	}
+
+	if _, ok := interval.NewSet().Min(); ok {
+		t.Errorf("empty set has a Min")
+	}
+}
+
+func TestSetShiftAndSplitAt(t *testing.T) {
+	s := interval.NewSet(iv(0, 3), iv(10, 12))
+
+	if got, want := s.Shift(5), (interval.Set{iv(5, 8), iv(15, 17)}); !slices.Equal(got, want) {
+		t.Errorf("%v.Shift(5) = %v, want %v", s, got, want)
+	}
+
+	tests := []struct {
+		x            int
+		below, above interval.Set
+	}{
+		{-1, nil, interval.Set{iv(0, 3), iv(10, 12)}},
+		{2, interval.Set{iv(0, 2)}, interval.Set{iv(2, 3), iv(10, 12)}},
+		{5, interval.Set{iv(0, 3)}, interval.Set{iv(10, 12)}},
+		{12, interval.Set{iv(0, 3), iv(10, 12)}, nil},
+	}
+
+	for _, tt := range tests {
+		below, above := s.SplitAt(tt.x)
+		if !slices.Equal(below, tt.below) || !slices.Equal(above, tt.above) {
+			t.Errorf("%v.SplitAt(%d) = %v, %v, want %v, %v", s, tt.x, below, above, tt.below, tt.above)
+		}
+	}
+}
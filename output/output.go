@@ -0,0 +1,235 @@
+// Package output writes answers in the machine-readable formats shared by
+// the aoc runner and every day's own main: a JSON array or TSV rows, one
+// per part, with year, day, part, answer, duration and input hash.
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/aoc"
+	"github.com/unkn0wn-root/advent_of_code_2023/input"
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// The supported formats. Text is each program's own human-readable output.
+const (
+	Text = "text"
+	JSON = "json"
+	TSV  = "tsv"
+)
+
+// Check returns an error unless format is one of the supported formats.
+func Check(format string) error {
+	switch format {
+	case Text, JSON, TSV:
+		return nil
+	default:
+		return fmt.Errorf("output: unknown format %q, want text, json or tsv", format)
+	}
+}
+
+// record is the stable shape of a result in JSON; TSV has the same columns.
+type record struct {
+	Year        int    `json:"year"`
+	Day         int    `json:"day"`
+	Title       string `json:"title"`
+	Part        int    `json:"part"`
+	Answer      string `json:"answer"`
+	DurationNS  int64  `json:"duration_ns"`
+	Input       string `json:"input"`
+	InputSHA256 string `json:"input_sha256"`
+	Cached      bool   `json:"cached,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// flatten keeps a TSV field on one line and in one column.
+var flatten = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+var tsvHeader = []string{"year", "day", "title", "part", "answer", "duration_ns", "input", "input_sha256", "cached", "error"}
+
+func newRecord(r aoc.Result) record {
+	rec := record{
+		Year:        r.Year,
+		Day:         r.Day,
+		Title:       r.Title,
+		Part:        r.Part,
+		Answer:      r.Answer,
+		DurationNS:  r.Duration.Nanoseconds(),
+		Input:       r.Input,
+		InputSHA256: r.InputHash,
+		Cached:      r.Cached,
+	}
+
+	if r.Err != nil {
+		rec.Error = r.Err.Error()
+	}
+
+	return rec
+}
+
+// Writer writes results in JSON or TSV. Close must be called once every
+// result is written.
+type Writer struct {
+	w       io.Writer
+	format  string
+	records []record
+	started bool
+}
+
+// NewWriter returns a writer of results to w in format, JSON or TSV.
+func NewWriter(w io.Writer, format string) (*Writer, error) {
+	if err := Check(format); err != nil {
+		return nil, err
+	}
+
+	if format == Text {
+		return nil, fmt.Errorf("output: %q is not a machine-readable format, want json or tsv", format)
+	}
+
+	return &Writer{w: w, format: format}, nil
+}
+
+// Write writes r. JSON is only written out by Close.
+func (w *Writer) Write(r aoc.Result) error {
+	rec := newRecord(r)
+	if w.format == JSON {
+		w.records = append(w.records, rec)
+		return nil
+	}
+
+	if !w.started {
+		w.started = true
+		if _, err := fmt.Fprintln(w.w, strings.Join(tsvHeader, "\t")); err != nil {
+			return err
+		}
+	}
+
+	row := []string{
+		strconv.Itoa(rec.Year),
+		strconv.Itoa(rec.Day),
+		rec.Title,
+		strconv.Itoa(rec.Part),
+		rec.Answer,
+		strconv.FormatInt(rec.DurationNS, 10),
+		rec.Input,
+		rec.InputSHA256,
+		strconv.FormatBool(rec.Cached),
+		rec.Error,
+	}
+
+	for i, field := range row {
+		row[i] = flatten.Replace(field)
+	}
+
+	_, err := fmt.Fprintln(w.w, strings.Join(row, "\t"))
+
+	return err
+}
+
+// Close finishes the output: the JSON array, or the TSV header when no
+// result was written.
+func (w *Writer) Close() error {
+	if w.format == TSV {
+		if !w.started {
+			_, err := fmt.Fprintln(w.w, strings.Join(tsvHeader, "\t"))
+			return err
+		}
+
+		return nil
+	}
+
+	records := w.records
+	if records == nil {
+		records = []record{}
+	}
+
+	enc := json.NewEncoder(w.w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+// Failed returns a result for each of parts recording that the input could
+// not be solved at all, for instance because it did not parse.
+func Failed(d aoc.Day, name, content string, parts []int, err error) []aoc.Result {
+	results := make([]aoc.Result, len(parts))
+	for i, part := range parts {
+		results[i] = aoc.Result{
+			Year:      aoc.Year,
+			Day:       d.Day,
+			Title:     d.Title,
+			Part:      part,
+			Input:     name,
+			InputHash: aoc.HashInput(content),
+			Err:       err,
+		}
+	}
+
+	return results
+}
+
+// Day solves both parts of the registered day on every input at path and
+// writes the results to w in format, JSON or TSV. It is what a day's own
+// main runs for its -format flag. Failures are written as results too; the
+// first one is also returned.
+func Day(w io.Writer, format string, day int, path string) error {
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		return fmt.Errorf("output: day %d is not registered", day)
+	}
+
+	return Run(w, format, d, path)
+}
+
+// Run is Day for a day that may not be registered as is, such as one whose
+// solver is configured by flags of the day's main.
+func Run(w io.Writer, format string, d aoc.Day, path string) error {
+	out, err := NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	sources, err := input.Resolve(path)
+	if err != nil {
+		return err
+	}
+
+	parts := []int{1, 2}
+
+	var failed error
+	for _, src := range sources {
+		content, err := src.Read()
+		if err != nil {
+			return parse.InFile(err, src.Name)
+		}
+
+		results, err := aoc.Run(context.Background(), d, src.Name, content, parts)
+		if err != nil {
+			err = parse.InFile(err, src.Name)
+			if len(results) == 0 {
+				results = Failed(d, src.Name, content, parts, err)
+			}
+
+			if failed == nil {
+				failed = err
+			}
+		}
+
+		for _, r := range results {
+			if err := out.Write(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return failed
+}
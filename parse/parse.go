@@ -0,0 +1,291 @@
+// Package parse is the small toolkit the puzzle parsers share. Its errors
+// carry the position of the offending token so that malformed input fails
+// loudly instead of turning into zeros and wrong answers.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a parse failure at a position in the puzzle input.
+type Error struct {
+	// File is the input the error was found in, empty when unknown.
+	File string
+	// Line and Column are 1-based. Column is 0 when the whole line is at fault.
+	Line, Column int
+	// Expected describes what the parser wanted, e.g. "integer" or "':'".
+	Expected string
+	// Found is the token found instead.
+	Found string
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		b.WriteByte(':')
+	}
+
+	fmt.Fprintf(&b, "%d:", e.Line)
+	if e.Column > 0 {
+		fmt.Fprintf(&b, "%d:", e.Column)
+	}
+
+	fmt.Fprintf(&b, " expected %s", e.Expected)
+	if e.Found == "" {
+		b.WriteString(", found end of line")
+	} else {
+		fmt.Fprintf(&b, ", found %q", e.Found)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// InFile attaches the input name to err. A parse *Error is returned as a
+// copy that records it as its File, and err itself is left alone. Any other
+// error, including one that wraps a parse *Error, is wrapped with the name
+// as prefix. An error that already names its file is returned as is.
+func InFile(err error, name string) error {
+	if err == nil {
+		return nil
+	}
+
+	var pe *Error
+	if errors.As(err, &pe) && pe.File != "" {
+		return err
+	}
+
+	if pe == nil || pe != err {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	named := *pe
+	named.File = name
+
+	return &named
+}
+
+// Cursor walks over a single line of input, consuming tokens from left to
+// right and reporting failures at its current column.
+type Cursor struct {
+	line int
+	text string
+	pos  int
+}
+
+// NewCursor returns a cursor at the start of text, which is line number line (1-based).
+func NewCursor(line int, text string) *Cursor {
+	return &Cursor{line: line, text: text}
+}
+
+// Line returns the 1-based line number of the cursor.
+func (c *Cursor) Line() int {
+	return c.line
+}
+
+// Column returns the 1-based column of the next unread byte.
+func (c *Cursor) Column() int {
+	return c.pos + 1
+}
+
+// Done reports whether the whole line has been consumed.
+func (c *Cursor) Done() bool {
+	return c.pos >= len(c.text)
+}
+
+// Rest returns the unread part of the line.
+func (c *Cursor) Rest() string {
+	return c.text[c.pos:]
+}
+
+// Errorf returns an error at the cursor position saying what was expected
+// there. The found token is the next word of the line.
+func (c *Cursor) Errorf(format string, args ...any) *Error {
+	return &Error{Line: c.line, Column: c.Column(), Expected: fmt.Sprintf(format, args...), Found: nextToken(c.Rest())}
+}
+
+// SkipSpaces consumes any spaces and tabs.
+func (c *Cursor) SkipSpaces() {
+	for c.pos < len(c.text) && (c.text[c.pos] == ' ' || c.text[c.pos] == '\t') {
+		c.pos++
+	}
+}
+
+// Accept consumes lit if the line continues with it and reports whether it did.
+func (c *Cursor) Accept(lit string) bool {
+	if strings.HasPrefix(c.Rest(), lit) {
+		c.pos += len(lit)
+		return true
+	}
+
+	return false
+}
+
+// Expect consumes lit or fails if the line does not continue with it.
+func (c *Cursor) Expect(lit string) error {
+	if !c.Accept(lit) {
+		return c.Errorf("%q", lit)
+	}
+
+	return nil
+}
+
+// Int consumes an optionally signed decimal integer.
+func (c *Cursor) Int() (int, error) {
+	end := c.pos
+	if end < len(c.text) && (c.text[end] == '-' || c.text[end] == '+') {
+		end++
+	}
+
+	for end < len(c.text) && isDigit(c.text[end]) {
+		end++
+	}
+
+	n, err := strconv.Atoi(c.text[c.pos:end])
+	if err != nil {
+		e := c.Errorf("integer")
+		if end > c.pos && isDigit(c.text[end-1]) {
+			// the digits were there but did not fit into an int.
+			e.Found, e.Err = c.text[c.pos:end], err
+		}
+
+		return 0, e
+	}
+
+	c.pos = end
+
+	return n, nil
+}
+
+// Word consumes a run of ASCII letters.
+func (c *Cursor) Word() (string, error) {
+	end := c.pos
+	for end < len(c.text) && isLetter(c.text[end]) {
+		end++
+	}
+
+	if end == c.pos {
+		return "", c.Errorf("word")
+	}
+
+	w := c.text[c.pos:end]
+	c.pos = end
+
+	return w, nil
+}
+
+// Until consumes everything up to, but not including, the first occurrence
+// of sep and fails if sep does not occur in the rest of the line.
+func (c *Cursor) Until(sep string) (string, error) {
+	i := strings.Index(c.Rest(), sep)
+	if i < 0 {
+		return "", &Error{Line: c.line, Column: len(c.text) + 1, Expected: fmt.Sprintf("%q", sep)}
+	}
+
+	s := c.text[c.pos : c.pos+i]
+	c.pos += i
+
+	return s, nil
+}
+
+// End fails unless the whole line has been consumed.
+func (c *Cursor) End() error {
+	if !c.Done() {
+		return c.Errorf("end of line")
+	}
+
+	return nil
+}
+
+// Ints consumes a list of integers separated by spaces until the end of the
+// line or the next byte that cannot start an integer.
+func (c *Cursor) Ints() ([]int, error) {
+	var nums []int
+	for {
+		c.SkipSpaces()
+		if c.Done() || !(isDigit(c.text[c.pos]) || c.text[c.pos] == '-' || c.text[c.pos] == '+') {
+			return nums, nil
+		}
+
+		n, err := c.Int()
+		if err != nil {
+			return nil, err
+		}
+
+		nums = append(nums, n)
+	}
+}
+
+// Rectangle checks that every line is as long as the first one. Lengths
+// are counted in characters, as a grid of text counts its tiles.
+func Rectangle(lines []string) error {
+	for y, line := range lines {
+		if width := utf8.RuneCountInString(lines[0]); utf8.RuneCountInString(line) != width {
+			return &Error{Line: y + 1, Expected: fmt.Sprintf("row of %d tiles", width), Found: line}
+		}
+	}
+
+	return nil
+}
+
+// Grid checks that lines form a rectangle made only of characters in
+// alphabet and returns the first offending position otherwise. Its column
+// is counted in characters too.
+func Grid(lines []string, alphabet string) error {
+	if err := Rectangle(lines); err != nil {
+		return err
+	}
+
+	for y, line := range lines {
+		for x, r := range []rune(line) {
+			if !strings.ContainsRune(alphabet, r) {
+				return &Error{Line: y + 1, Column: x + 1, Expected: "one of " + strconv.Quote(alphabet), Found: string(r)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// nextToken returns the text up to the next space, or the first byte when
+// the text starts with a separator.
+func nextToken(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	end := strings.IndexAny(s, " \t")
+	if end == 0 {
+		return s[:1]
+	}
+
+	if end < 0 {
+		end = len(s)
+	}
+
+	if i := strings.IndexAny(s[:end], ",;:|{}()="); i > 0 {
+		end = i
+	} else if i == 0 {
+		end = 1
+	}
+
+	return s[:end]
+}
@@ -0,0 +1,217 @@
+package parse_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/unkn0wn-root/advent_of_code_2023/parse"
+)
+
+// wantError checks that err is a *parse.Error at line 1 and column, saying
+// what was expected and found.
+func wantError(t *testing.T, err error, column int, expected, found string) {
+	t.Helper()
+
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("error = %v, want a *parse.Error", err)
+	}
+
+	if perr.Line != 1 || perr.Column != column || perr.Expected != expected || perr.Found != found {
+		t.Errorf("error at %d:%d expecting %s and finding %q, want 1:%d expecting %s and finding %q",
+			perr.Line, perr.Column, perr.Expected, perr.Found, column, expected, found)
+	}
+}
+
+func TestCursorInt(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+		rest string
+	}{
+		{"42", 42, ""},
+		{"-7 x", -7, " x"},
+		{"+3,4", 3, ",4"},
+		{"007", 7, ""},
+	}
+
+	for _, tt := range tests {
+		c := parse.NewCursor(1, tt.text)
+		if got, err := c.Int(); err != nil || got != tt.want || c.Rest() != tt.rest {
+			t.Errorf("Int() on %q = %d, %v leaving %q, want %d leaving %q", tt.text, got, err, c.Rest(), tt.want, tt.rest)
+		}
+	}
+
+	bad := []struct {
+		text, skip string
+		column     int
+		found      string
+	}{
+		{"abc", "", 1, "abc"},
+		{"x: y1", "x: ", 4, "y1"},
+		{"-", "", 1, "-"},
+		{"", "", 1, ""},
+		{"n 99999999999999999999999", "n ", 3, "99999999999999999999999"},
+	}
+
+	for _, tt := range bad {
+		c := parse.NewCursor(1, tt.text)
+		c.Accept(tt.skip)
+
+		_, err := c.Int()
+		wantError(t, err, tt.column, "integer", tt.found)
+
+		if c.Column() != tt.column {
+			t.Errorf("a failed Int() on %q moved the cursor to column %d", tt.text, c.Column())
+		}
+	}
+
+	var numErr *strconv.NumError
+	if _, err := parse.NewCursor(1, "99999999999999999999999").Int(); !errors.As(err, &numErr) {
+		t.Errorf("an Int() that overflows does not wrap the strconv error: %v", err)
+	}
+}
+
+func TestCursorWord(t *testing.T) {
+	c := parse.NewCursor(1, "red, 3")
+	if w, err := c.Word(); err != nil || w != "red" || c.Column() != 4 {
+		t.Errorf("Word() = %q, %v at column %d, want red at column 4", w, err, c.Column())
+	}
+
+	_, err := c.Word()
+	wantError(t, err, 4, "word", ",")
+}
+
+func TestCursorExpectAndEnd(t *testing.T) {
+	c := parse.NewCursor(1, "Game 12: 3 blue")
+
+	if err := c.Expect("Game "); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Int(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantError(t, c.Expect(";"), 8, `";"`, ":")
+	wantError(t, c.End(), 8, "end of line", ":")
+
+	if !c.Accept(": 3 blue") || c.End() != nil || !c.Done() {
+		t.Errorf("End() fails after consuming the whole line")
+	}
+
+	if c.Accept("more") {
+		t.Errorf("Accept succeeded at the end of the line")
+	}
+
+	wantError(t, c.Expect("x"), 16, `"x"`, "")
+}
+
+func TestCursorUntilAndInts(t *testing.T) {
+	c := parse.NewCursor(1, "R 6 (#70c710)")
+	if s, err := c.Until(" "); err != nil || s != "R" || c.Column() != 2 {
+		t.Errorf("Until(space) = %q, %v at column %d, want R at column 2", s, err, c.Column())
+	}
+
+	_, err := c.Until("]")
+	wantError(t, err, 14, `"]"`, "")
+
+	c = parse.NewCursor(1, "seeds: 79 -14  55 x")
+	c.Expect("seeds:")
+	if got, err := c.Ints(); err != nil || !slices.Equal(got, []int{79, -14, 55}) || c.Rest() != "x" {
+		t.Errorf("Ints() = %v, %v leaving %q, want [79 -14 55] leaving x", got, err, c.Rest())
+	}
+}
+
+func TestErrorString(t *testing.T) {
+	tests := []struct {
+		err  *parse.Error
+		want string
+	}{
+		{&parse.Error{Line: 3, Column: 5, Expected: "integer", Found: "x"}, `3:5: expected integer, found "x"`},
+		{&parse.Error{File: "in.txt", Line: 3, Expected: "row of 4 tiles", Found: "..."}, `in.txt:3: expected row of 4 tiles, found "..."`},
+		{&parse.Error{Line: 1, Column: 9, Expected: `":"`}, `1:9: expected ":", found end of line`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestInFile(t *testing.T) {
+	if parse.InFile(nil, "in.txt") != nil {
+		t.Errorf("InFile(nil) is not nil")
+	}
+
+	orig := &parse.Error{Line: 2, Column: 1, Expected: "integer", Found: "x"}
+	named := parse.InFile(orig, "in.txt")
+	if got := named.Error(); got != `in.txt:2:1: expected integer, found "x"` {
+		t.Errorf("InFile = %s", got)
+	}
+
+	if orig.File != "" {
+		t.Errorf("InFile changed the original error to name %q", orig.File)
+	}
+
+	if again := parse.InFile(named, "other.txt"); again != named {
+		t.Errorf("InFile renamed an error that already names its file: %v", again)
+	}
+
+	wrapped := fmt.Errorf("part 1: %w", orig)
+	if got := parse.InFile(wrapped, "in.txt"); !errors.Is(got, orig) || got.Error() != `in.txt: part 1: 2:1: expected integer, found "x"` {
+		t.Errorf("InFile(wrapped) = %v", got)
+	}
+
+	plain := errors.New("boom")
+	if got := parse.InFile(plain, "in.txt"); !errors.Is(got, plain) || got.Error() != "in.txt: boom" {
+		t.Errorf("InFile(plain) = %v", got)
+	}
+}
+
+func TestRectangleAndGrid(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		alphabet string
+		line     int // 0 when the lines are valid
+		column   int
+	}{
+		{"rectangle", []string{"ab", "cd"}, "", 0, 0},
+		{"no lines", nil, "", 0, 0},
+		{"ragged", []string{"ab", "c"}, "", 2, 0},
+		{"characters, not bytes", []string{"é.", "..", "éé"}, "", 0, 0},
+		{"ragged in characters", []string{"é", ".."}, "", 2, 0},
+		{"alphabet", []string{".#", "#."}, ".#", 0, 0},
+		{"outside alphabet", []string{".#", "#x"}, ".#", 2, 2},
+		{"column in characters", []string{"é.x"}, "é.", 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.alphabet == "" {
+				err = parse.Rectangle(tt.lines)
+			} else {
+				err = parse.Grid(tt.lines, tt.alphabet)
+			}
+
+			if tt.line == 0 {
+				if err != nil {
+					t.Errorf("error = %v, want none", err)
+				}
+
+				return
+			}
+
+			var perr *parse.Error
+			if !errors.As(err, &perr) || perr.Line != tt.line || perr.Column != tt.column {
+				t.Errorf("error = %v, want a *parse.Error at %d:%d", err, tt.line, tt.column)
+			}
+		})
+	}
+}